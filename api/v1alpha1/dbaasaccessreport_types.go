@@ -0,0 +1,104 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBaaSAccessLevel is the level of access a subject has to an inventory
+type DBaaSAccessLevel string
+
+const (
+	// AccessLevelAdmin subjects can create and edit inventories, and their credentials secrets, in the tenant namespace
+	AccessLevelAdmin DBaaSAccessLevel = "Admin"
+	// AccessLevelDeveloper subjects can read the inventory, and create connections and instances referencing it
+	AccessLevelDeveloper DBaaSAccessLevel = "Developer"
+	// AccessLevelTenantViewer subjects can read the tenants of the inventory namespace
+	AccessLevelTenantViewer DBaaSAccessLevel = "TenantViewer"
+)
+
+// DBaaSAccessReportStatus lists the subjects with access to an inventory
+type DBaaSAccessReportStatus struct {
+	// Time the report was generated. Reports are refreshed whenever the operator reconciles the access to
+	// the inventory namespace, and only regenerated when the access they list has changed.
+	GeneratedAt *metav1.Time `json:"generatedAt,omitempty"`
+
+	// Tenants of the inventory namespace
+	Tenants []string `json:"tenants,omitempty"`
+
+	// Subjects with access to the inventory, and the RBAC bindings granting it
+	Entries []DBaaSAccessReportEntry `json:"entries,omitempty"`
+}
+
+// DBaaSAccessReportEntry defines the level of access a subject has to an inventory
+type DBaaSAccessReportEntry struct {
+	// Level of access of the subject
+	AccessLevel DBaaSAccessLevel `json:"accessLevel"`
+
+	// User, group or service account with access
+	Subject rbacv1.Subject `json:"subject"`
+
+	// RBAC bindings granting the access. Empty when the access is granted other than through RBAC bindings,
+	// for example by group membership resolved by the authorization backend.
+	GrantedBy []DBaaSAccessGrant `json:"grantedBy,omitempty"`
+}
+
+// DBaaSAccessGrant references an RBAC binding granting access
+type DBaaSAccessGrant struct {
+	// Kind of the binding, either RoleBinding or ClusterRoleBinding
+	Kind string `json:"kind"`
+
+	// Name of the binding
+	Name string `json:"name"`
+
+	// Namespace of the binding, empty for cluster role bindings
+	Namespace string `json:"namespace,omitempty"`
+
+	// Role referenced by the binding
+	RoleRef rbacv1.RoleRef `json:"roleRef"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Generated",type=date,JSONPath=`.status.generatedAt`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:subresource:status
+
+//+operator-sdk:csv:customresourcedefinitions:displayName="Provider Account Access Report"
+// DBaaSAccessReport is the Schema for the dbaasaccessreports API. Access reports are read-only, and are generated
+// by the operator for every inventory, with the same name and namespace. Any write to the status of a report is
+// overwritten by the operator with the generated status, and deleted reports are regenerated.
+type DBaaSAccessReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DBaaSAccessReportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBaaSAccessReportList contains a list of DBaaSAccessReports
+type DBaaSAccessReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSAccessReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSAccessReport{}, &DBaaSAccessReportList{})
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -29,20 +32,25 @@ import (
 // log is for logging in this package.
 var dbaasconnectionlog = logf.Log.WithName("dbaasconnection-resource")
 
+var connectionWebhookApiClient client.Client
+
 func (r *DBaaSConnection) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if connectionWebhookApiClient == nil {
+		connectionWebhookApiClient = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
-//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasconnections,verbs=update,versions=v1alpha1,name=vdbaasconnection.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasconnections,verbs=create;update,versions=v1alpha1,name=vdbaasconnection.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &DBaaSConnection{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSConnection) ValidateCreate() error {
 	dbaasconnectionlog.Info("validate create", "name", r.Name)
-	return nil
+	return r.validateConnectionQuota()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -68,3 +76,31 @@ func (r *DBaaSConnection) validateUpdateDBaaSConnectionSpec(old *DBaaSConnection
 
 	return nil
 }
+
+func (r *DBaaSConnection) validateConnectionQuota() error {
+	inventory, tenant, err := getInventoryTenant(connectionWebhookApiClient, r.Spec.InventoryRef, r.Namespace)
+	if err != nil {
+		return err
+	}
+	// inventory or tenant not found, the connection controller reports the error in the connection status
+	if inventory == nil || tenant == nil || tenant.Spec.Quotas == nil || tenant.Spec.Quotas.MaxConnectionsPerNamespace == nil {
+		return nil
+	}
+
+	connectionList := &DBaaSConnectionList{}
+	if err := connectionWebhookApiClient.List(context.TODO(), connectionList, client.InNamespace(r.Namespace)); err != nil {
+		return err
+	}
+	count := int32(0)
+	for i := range connectionList.Items {
+		if InventoryRefNamespace(connectionList.Items[i].Spec.InventoryRef, connectionList.Items[i].Namespace) == tenant.Spec.InventoryNamespace {
+			count++
+		}
+	}
+	if count >= *tenant.Spec.Quotas.MaxConnectionsPerNamespace {
+		errMsg := fmt.Sprintf("tenant %s allows at most %d connections in namespace %s, %d already exist", tenant.Name, *tenant.Spec.Quotas.MaxConnectionsPerNamespace, r.Namespace, count)
+		return field.Forbidden(field.NewPath("spec").Child("inventoryRef"), errMsg)
+	}
+
+	return nil
+}
@@ -20,8 +20,35 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DBaaSInstanceDeletionPolicy determines what happens to the provider instance when an expired DBaaSInstance is deleted
+type DBaaSInstanceDeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the provider instance along with the DBaaSInstance, deprovisioning the database
+	DeletionPolicyDelete DBaaSInstanceDeletionPolicy = "Delete"
+	// DeletionPolicyOrphan orphans the provider instance when the DBaaSInstance is deleted, leaving the database provisioned
+	DeletionPolicyOrphan DBaaSInstanceDeletionPolicy = "Orphan"
+)
+
+// DBaaSOperatorInstanceSpec defines the desired state of DBaaSInstance
+type DBaaSOperatorInstanceSpec struct {
+	// Duration after the instance's creation at which the instance expires and is deleted (e.g. "72h")
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Time at which the instance expires and is deleted. Takes precedence over ttl.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Determines what happens to the provider instance when the DBaaSInstance expires, defaults to Delete
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DBaaSInstanceDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// The properties that will be copied into the provider’s instance Spec
+	DBaaSInstanceSpec `json:",inline"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.spec.expiresAt`
 
 //+operator-sdk:csv:customresourcedefinitions:displayName="DBaaSInstance"
 // DBaaSInstance is the Schema for the dbaasinstances API
@@ -29,8 +56,8 @@ type DBaaSInstance struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   DBaaSInstanceSpec   `json:"spec,omitempty"`
-	Status DBaaSInstanceStatus `json:"status,omitempty"`
+	Spec   DBaaSOperatorInstanceSpec `json:"spec,omitempty"`
+	Status DBaaSInstanceStatus       `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,236 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasinstancelog = logf.Log.WithName("dbaasinstance-resource")
+
+var instanceWebhookApiClient client.Client
+
+func (r *DBaaSInstance) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if instanceWebhookApiClient == nil {
+		instanceWebhookApiClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create;update,versions=v1alpha1,name=vdbaasinstance.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &DBaaSInstance{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateCreate() error {
+	dbaasinstancelog.Info("validate create", "name", r.Name)
+	return r.validateInstance(true)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateUpdate(old runtime.Object) error {
+	dbaasinstancelog.Info("validate update", "name", r.Name)
+	oldInstance := old.(*DBaaSInstance)
+	// the quotas of the tenant of the inventory are only checked on creation
+	if !reflect.DeepEqual(r.Spec.InventoryRef, oldInstance.Spec.InventoryRef) {
+		return field.Invalid(field.NewPath("spec").Child("inventoryRef"), r.Spec.InventoryRef, "inventoryRef is immutable")
+	}
+	// only re-validate when the spec changes, so that tightening a tenant's policy doesn't block unrelated updates
+	if reflect.DeepEqual(r.Spec, oldInstance.Spec) {
+		return nil
+	}
+	return r.validateInstance(false)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateDelete() error {
+	dbaasinstancelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *DBaaSInstance) validateInstance(creating bool) error {
+	inventory, tenant, err := getInventoryTenant(instanceWebhookApiClient, r.Spec.InventoryRef, r.Namespace)
+	if err != nil {
+		return err
+	}
+	// inventory or tenant not found, the instance controller reports the error in the instance status
+	if inventory == nil || tenant == nil {
+		return nil
+	}
+
+	if err := validateInstanceAllowedValues(r, inventory, tenant.Spec.AllowedValues); err != nil {
+		return err
+	}
+
+	provider := &DBaaSProvider{}
+	if err := instanceWebhookApiClient.Get(context.TODO(), types.NamespacedName{Name: inventory.Spec.ProviderRef.Name}, provider); err != nil {
+		// provider not found, the instance controller reports the error in the instance status
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		if err := validateInstanceCapabilities(r, provider, creating); err != nil {
+			return err
+		}
+		if err := validateInstanceParameters(r, provider); err != nil {
+			return err
+		}
+	}
+
+	if creating && tenant.Spec.Quotas != nil && tenant.Spec.Quotas.MaxInstances != nil {
+		instanceList := &DBaaSInstanceList{}
+		if err := instanceWebhookApiClient.List(context.TODO(), instanceList); err != nil {
+			return err
+		}
+		count := int32(0)
+		for i := range instanceList.Items {
+			if InventoryRefNamespace(instanceList.Items[i].Spec.InventoryRef, instanceList.Items[i].Namespace) == tenant.Spec.InventoryNamespace {
+				count++
+			}
+		}
+		if count >= *tenant.Spec.Quotas.MaxInstances {
+			errMsg := fmt.Sprintf("tenant %s allows at most %d instances, %d already exist", tenant.Name, *tenant.Spec.Quotas.MaxInstances, count)
+			return field.Forbidden(field.NewPath("spec").Child("inventoryRef"), errMsg)
+		}
+	}
+
+	return nil
+}
+
+func validateInstanceAllowedValues(instance *DBaaSInstance, inventory *DBaaSInventory, allowed *DBaaSTenantAllowedValues) error {
+	if allowed == nil {
+		return nil
+	}
+	specPath := field.NewPath("spec")
+	if len(allowed.Providers) > 0 && !containsString(allowed.Providers, inventory.Spec.ProviderRef.Name) {
+		return field.NotSupported(specPath.Child("inventoryRef"), inventory.Spec.ProviderRef.Name, allowed.Providers)
+	}
+	if len(allowed.CloudProviders) > 0 && !containsString(allowed.CloudProviders, instance.Spec.CloudProvider) {
+		return field.NotSupported(specPath.Child("cloudProvider"), instance.Spec.CloudProvider, allowed.CloudProviders)
+	}
+	if len(allowed.CloudRegions) > 0 && !containsString(allowed.CloudRegions, instance.Spec.CloudRegion) {
+		return field.NotSupported(specPath.Child("cloudRegion"), instance.Spec.CloudRegion, allowed.CloudRegions)
+	}
+
+	// sort the keys for deterministic error messages
+	keys := make([]string, 0, len(allowed.InstanceParams))
+	for key := range allowed.InstanceParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values := allowed.InstanceParams[key]
+		if value, ok := instance.Spec.OtherInstanceParams[key]; ok && len(values) > 0 && !containsString(values, value) {
+			return field.NotSupported(specPath.Child("otherInstanceParams").Key(key), value, values)
+		}
+	}
+	return nil
+}
+
+// checks that the provider supports provisioning the instance, in its cloud provider and region, and deleting it on expiry
+func validateInstanceCapabilities(instance *DBaaSInstance, provider *DBaaSProvider, creating bool) error {
+	capabilities := provider.Spec.GetCapabilities()
+	specPath := field.NewPath("spec")
+	if creating && !capabilities.SupportsProvisioning() {
+		errMsg := fmt.Sprintf("provider %s does not support provisioning instances", provider.Name)
+		return field.Forbidden(specPath.Child("inventoryRef"), errMsg)
+	}
+	if !capabilities.SupportsDeletion() && instance.Spec.DeletionPolicy != DeletionPolicyOrphan &&
+		(instance.Spec.TTL != nil || instance.Spec.ExpiresAt != nil) {
+		errMsg := fmt.Sprintf("provider %s does not support deleting instances, expiring instances must use the %s deletion policy",
+			provider.Name, DeletionPolicyOrphan)
+		return field.Forbidden(specPath.Child("deletionPolicy"), errMsg)
+	}
+
+	if len(capabilities.CloudProviders) == 0 {
+		return nil
+	}
+	var cloudProviders []string
+	for _, cloudProvider := range capabilities.CloudProviders {
+		cloudProviders = append(cloudProviders, cloudProvider.Name)
+		if cloudProvider.Name != instance.Spec.CloudProvider {
+			continue
+		}
+		if len(cloudProvider.Regions) > 0 && !containsString(cloudProvider.Regions, instance.Spec.CloudRegion) {
+			return field.NotSupported(specPath.Child("cloudRegion"), instance.Spec.CloudRegion, cloudProvider.Regions)
+		}
+		return nil
+	}
+	return field.NotSupported(specPath.Child("cloudProvider"), instance.Spec.CloudProvider, cloudProviders)
+}
+
+// checks that the instance parameters which are set, and shown given the other parameters, are valid for their spec.
+// The name, cloudProvider and cloudRegion parameters are set by the fields of the same name of the instance spec.
+func validateInstanceParameters(instance *DBaaSInstance, provider *DBaaSProvider) error {
+	specPath := field.NewPath("spec")
+	values := map[string]string{}
+	paths := map[string]*field.Path{}
+	for name, value := range instance.Spec.OtherInstanceParams {
+		values[name] = value
+		paths[name] = specPath.Child("otherInstanceParams").Key(name)
+	}
+	for name, value := range map[string]string{
+		"name":          instance.Spec.Name,
+		"cloudProvider": instance.Spec.CloudProvider,
+		"cloudRegion":   instance.Spec.CloudRegion,
+	} {
+		if len(value) > 0 {
+			values[name] = value
+			paths[name] = specPath.Child(name)
+		}
+	}
+
+	for _, parameterSpec := range provider.Spec.InstanceParameterSpecs {
+		value, ok := values[parameterSpec.Name]
+		if !ok || !isFieldVisible(parameterSpec.FieldMetadata, values) {
+			continue
+		}
+		if err := validateFieldValue(parameterSpec.Type, parameterSpec.FieldMetadata, value); err != nil {
+			if strings.EqualFold(parameterSpec.Type, FieldTypeMaskedString) {
+				value = "<masked>"
+			}
+			return field.Invalid(paths[parameterSpec.Name], value, err.Error())
+		}
+	}
+	return nil
+}
+
+// checks if a string is present in a slice
+func containsString(s []string, str string) bool {
+	for _, v := range s {
+		if v == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	testQuotaTenant = &DBaaSTenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-quota-tenant",
+		},
+		Spec: DBaaSTenantSpec{
+			InventoryNamespace: testNamespace,
+			Quotas: &DBaaSTenantQuotas{
+				MaxInstances:               pointer.Int32(1),
+				MaxConnectionsPerNamespace: pointer.Int32(1),
+			},
+			AllowedValues: &DBaaSTenantAllowedValues{
+				Providers:      []string{testProviderName},
+				CloudProviders: []string{"AWS"},
+				CloudRegions:   []string{"US_EAST_1"},
+				InstanceParams: map[string][]string{
+					"instanceSizeName": {"M0"},
+				},
+			},
+		},
+	}
+	testDBaaSInstance = &DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSOperatorInstanceSpec{
+			DBaaSInstanceSpec: DBaaSInstanceSpec{
+				InventoryRef: NamespacedName{
+					Name:      inventoryName,
+					Namespace: testNamespace,
+				},
+				Name:          "test-instance",
+				CloudProvider: "AWS",
+				CloudRegion:   "US_EAST_1",
+				OtherInstanceParams: map[string]string{
+					"instanceSizeName": "M0",
+				},
+			},
+		},
+	}
+)
+
+var _ = Describe("DBaaSInstance Webhook", func() {
+	BeforeEach(assertResourceCreation(&testSecret))
+	BeforeEach(assertResourceCreation(&testProvider))
+	BeforeEach(assertResourceCreation(&testDBaaSInventory))
+	BeforeEach(assertResourceCreation(testQuotaTenant))
+	AfterEach(assertResourceDeletion(testQuotaTenant))
+	AfterEach(assertResourceDeletion(&testDBaaSInventory))
+	AfterEach(assertResourceDeletion(&testProvider))
+	AfterEach(assertResourceDeletion(&testSecret))
+
+	Context("after creating DBaaSInstance", func() {
+		BeforeEach(assertResourceCreation(testDBaaSInstance))
+		AfterEach(assertResourceDeletion(testDBaaSInstance))
+
+		It("should not allow exceeding the tenant's instance quota", func() {
+			instance := testDBaaSInstance.DeepCopy()
+			instance.Name = "test-instance-2"
+			instance.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.inventoryRef: Forbidden: tenant test-quota-tenant allows at most 1 instances, 1 already exist"))
+		})
+
+		It("should not allow exceeding the tenant's connection quota", func() {
+			connection := testDBaaSConnection.DeepCopy()
+			connection.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, connection)).Should(Succeed())
+			defer assertResourceDeletion(connection)()
+
+			connection2 := testDBaaSConnection.DeepCopy()
+			connection2.Name = "test-connection-2"
+			connection2.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, connection2)).Should(MatchError("admission webhook \"vdbaasconnection.kb.io\" denied the request: " +
+				"spec.inventoryRef: Forbidden: tenant test-quota-tenant allows at most 1 connections in namespace default, 1 already exist"))
+		})
+
+		It("should allow updates which don't change the spec", func() {
+			instance := &DBaaSInstance{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSInstance), instance)).Should(Succeed())
+			instance.Labels = map[string]string{"test": "label"}
+			Expect(k8sClient.Update(ctx, instance)).Should(Succeed())
+		})
+
+		It("should not allow updating the inventoryRef", func() {
+			instance := &DBaaSInstance{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSInstance), instance)).Should(Succeed())
+			instance.Spec.InventoryRef.Name = "updated-inventory"
+			Expect(k8sClient.Update(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.inventoryRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"updated-inventory\"}: " +
+				"inventoryRef is immutable"))
+		})
+	})
+
+	DescribeTable("checking DBaaSInstances with values not allowed by the tenant",
+		func(specUpdateFn func(*DBaaSInstanceSpec), expectedErr string) {
+			instance := testDBaaSInstance.DeepCopy()
+			instance.SetResourceVersion("")
+			specUpdateFn(&instance.Spec.DBaaSInstanceSpec)
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError(expectedErr))
+		},
+		Entry("not allow a cloud provider",
+			func(spec *DBaaSInstanceSpec) {
+				spec.CloudProvider = "GCP"
+			},
+			"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+				"spec.cloudProvider: Unsupported value: \"GCP\": supported values: \"AWS\""),
+		Entry("not allow a cloud region",
+			func(spec *DBaaSInstanceSpec) {
+				spec.CloudRegion = "EU_WEST_1"
+			},
+			"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+				"spec.cloudRegion: Unsupported value: \"EU_WEST_1\": supported values: \"US_EAST_1\""),
+		Entry("not allow an instance parameter value",
+			func(spec *DBaaSInstanceSpec) {
+				spec.OtherInstanceParams["instanceSizeName"] = "M10"
+			},
+			"admission webhook \"vdbaasinstance.kb.io\" denied the request: "+
+				"spec.otherInstanceParams[instanceSizeName]: Unsupported value: \"M10\": supported values: \"M0\""),
+	)
+
+	Context("with a provider declaring its capabilities", func() {
+		setCapabilities := func(capabilities *DBaaSProviderCapabilities) func() {
+			return func() {
+				provider := &DBaaSProvider{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvider), provider)).Should(Succeed())
+				provider.Spec.Capabilities = capabilities
+				Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+			}
+		}
+		AfterEach(setCapabilities(nil))
+
+		It("should not allow provisioning instances if the provider does not support it", func() {
+			setCapabilities(&DBaaSProviderCapabilities{Provisioning: pointer.Bool(false), Deletion: pointer.Bool(true)})()
+			instance := testDBaaSInstance.DeepCopy()
+			instance.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.inventoryRef: Forbidden: provider " + testProviderName + " does not support provisioning instances"))
+		})
+
+		It("should not allow a cloud region not supported by the provider", func() {
+			setCapabilities(&DBaaSProviderCapabilities{
+				Provisioning: pointer.Bool(true),
+				Deletion:     pointer.Bool(true),
+				CloudProviders: []DBaaSProviderCloudProvider{
+					{Name: "AWS", Regions: []string{"US_WEST_2"}},
+				},
+			})()
+			instance := testDBaaSInstance.DeepCopy()
+			instance.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.cloudRegion: Unsupported value: \"US_EAST_1\": supported values: \"US_WEST_2\""))
+		})
+
+		It("should not allow instance parameters out of their range", func() {
+			provider := &DBaaSProvider{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvider), provider)).Should(Succeed())
+			provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{
+				{Name: "diskSizeGB", Type: FieldTypeInteger, FieldMetadata: FieldMetadata{Minimum: pointer.Int64(10)}},
+			}
+			Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+			defer func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvider), provider)).Should(Succeed())
+				provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{}
+				Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+			}()
+
+			instance := testDBaaSInstance.DeepCopy()
+			instance.SetResourceVersion("")
+			instance.Spec.OtherInstanceParams["diskSizeGB"] = "5"
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.otherInstanceParams[diskSizeGB]: Invalid value: \"5\": must be at least 10"))
+		})
+
+		It("should validate the instance spec fields against the instance parameters of the same name", func() {
+			provider := &DBaaSProvider{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvider), provider)).Should(Succeed())
+			provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{
+				{Name: "cloudRegion", Type: FieldTypeString, FieldMetadata: FieldMetadata{Options: []FieldOption{{Value: "US_WEST_2"}}}},
+			}
+			Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+			defer func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvider), provider)).Should(Succeed())
+				provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{}
+				Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+			}()
+
+			instance := testDBaaSInstance.DeepCopy()
+			instance.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.cloudRegion: Invalid value: \"US_EAST_1\": must be one of: US_WEST_2"))
+		})
+
+		It("should not allow expiring instances if the provider does not support deletion", func() {
+			setCapabilities(&DBaaSProviderCapabilities{Provisioning: pointer.Bool(true), Deletion: pointer.Bool(false)})()
+			instance := testDBaaSInstance.DeepCopy()
+			instance.SetResourceVersion("")
+			instance.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+				"spec.deletionPolicy: Forbidden: provider " + testProviderName + " does not support deleting instances, " +
+				"expiring instances must use the Orphan deletion policy"))
+		})
+	})
+})
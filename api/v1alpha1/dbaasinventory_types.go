@@ -30,6 +30,10 @@ type DBaaSOperatorInventorySpec struct {
 	// If not set in either the tenant or inventory object, connections will only be allowed in the inventory namespace.
 	ConnectionNamespaces []string `json:"connectionNamespaces,omitempty"`
 
+	// Selector for namespaces in which DBaaSConnections/DBaaSInstances are allowed to reference this Inventory,
+	// in addition to connectionNamespaces. If either is set, both corresponding DBaaSTenant settings are overridden.
+	ConnectionNamespaceSelector *metav1.LabelSelector `json:"connectionNamespaceSelector,omitempty"`
+
 	// The properties that will be copied into the provider’s inventory Spec
 	DBaaSInventorySpec `json:",inline"`
 }
@@ -66,6 +66,9 @@ func (r *DBaaSInventory) ValidateDelete() error {
 }
 
 func validateInventory(inv *DBaaSInventory) error {
+	if err := validateConnectionNamespaceSelector(inv.Spec.ConnectionNamespaceSelector); err != nil {
+		return err
+	}
 	// Retrieve the secret object
 	secret := &corev1.Secret{}
 	ns := inv.Spec.DBaaSInventorySpec.CredentialsRef.Namespace
@@ -84,13 +87,27 @@ func validateInventory(inv *DBaaSInventory) error {
 }
 
 func validateInventoryMandatoryFields(inv *DBaaSInventory, secret *corev1.Secret, provider *DBaaSProvider) error {
+	values := map[string]string{}
+	for key, value := range secret.Data {
+		values[key] = string(value)
+	}
 	for _, credField := range provider.Spec.CredentialFields {
-		if credField.Required {
-			if value, ok := secret.Data[credField.Key]; !ok || len(value) == 0 {
+		// hidden fields are neither required nor validated
+		if !isFieldVisible(credField.FieldMetadata, values) {
+			continue
+		}
+		value, ok := values[credField.Key]
+		if !ok || len(value) == 0 {
+			if credField.Required {
 				//Required key is missing
 				msg := fmt.Sprintf("credentialsRef is invalid: %s is required in secret %s", credField.Key, secret.Name)
 				return field.Invalid(field.NewPath("spec").Child("credentialsRef"), *(inv.Spec.CredentialsRef), msg)
 			}
+			continue
+		}
+		if err := validateFieldValue(credField.Type, credField.FieldMetadata, value); err != nil {
+			msg := fmt.Sprintf("credentialsRef is invalid: %s in secret %s %v", credField.Key, secret.Name, err)
+			return field.Invalid(field.NewPath("spec").Child("credentialsRef"), *(inv.Spec.CredentialsRef), msg)
 		}
 	}
 	return nil
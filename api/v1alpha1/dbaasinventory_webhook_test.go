@@ -217,6 +217,41 @@ var _ = Describe("DBaaSInventory Webhook", func() {
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.credentialsRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"testsecret\"}: credentialsRef is invalid: field1 is required in secret testsecret"))
 			})
 		})
+	Context("with credential field form metadata", func() {
+		updateCredentialFields := func(updateFn func([]CredentialField)) {
+			provider := &DBaaSProvider{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvider), provider)).Should(Succeed())
+			updateFn(provider.Spec.CredentialFields)
+			Expect(k8sClient.Update(ctx, provider)).Should(Succeed())
+		}
+		BeforeEach(assertResourceCreation(&testProvider))
+		AfterEach(assertResourceDeletion(&testProvider))
+		Context("with a value not matching the field pattern", func() {
+			BeforeEach(assertResourceCreation(&testSecret))
+			AfterEach(assertResourceDeletion(&testSecret))
+			It("should not allow creating the inventory", func() {
+				updateCredentialFields(func(fields []CredentialField) {
+					fields[0].Pattern = "[a-z]+"
+				})
+				inv := testDBaaSInventory.DeepCopy()
+				inv.SetResourceVersion("")
+				Expect(k8sClient.Create(ctx, inv)).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.credentialsRef: Invalid value: v1alpha1.NamespacedName{Namespace:\"default\", Name:\"testsecret\"}: credentialsRef is invalid: field1 in secret testsecret must match the pattern [a-z]+"))
+			})
+		})
+		Context("with a hidden required field", func() {
+			BeforeEach(assertResourceCreation(&testSecret2))
+			AfterEach(assertResourceDeletion(&testSecret2))
+			It("should allow creating the inventory without the field", func() {
+				updateCredentialFields(func(fields []CredentialField) {
+					fields[0].VisibleWhen = &FieldCondition{Field: "field2", Values: []string{"other"}}
+				})
+				inv := testDBaaSInventory.DeepCopy()
+				inv.SetResourceVersion("")
+				Expect(k8sClient.Create(ctx, inv)).Should(Succeed())
+				assertResourceDeletion(inv)()
+			})
+		})
+	})
 	Context("update",
 		func() {
 			BeforeEach(assertResourceCreation(&testSecret))
@@ -46,6 +46,19 @@ const (
 	ResultInProgress PlatformsInstlnStatus = "in progress"
 )
 
+type UninstallPhase string
+
+const (
+	UninstallDeletingConnections   UninstallPhase = "DeletingConnections"
+	UninstallDeletingInstances     UninstallPhase = "DeletingInstances"
+	UninstallDeletingInventories   UninstallPhase = "DeletingInventories"
+	UninstallUninstallingPlatforms UninstallPhase = "UninstallingPlatforms"
+	UninstallComplete              UninstallPhase = "Complete"
+)
+
+// SyncPeriodEnvName is the environment variable of the provider operators set from the sync period
+const SyncPeriodEnvName = "SYNC_PERIOD_MIN"
+
 type PlatformConfig struct {
 	Name           string
 	CSV            string
@@ -56,6 +69,8 @@ type PlatformConfig struct {
 	DisplayName    string
 	Envs           []v1.EnvVar
 	Type           PlatformsType
+	// The RELATED_IMAGE_* environment variable overriding the image
+	RelatedImageEnv string
 }
 
 // DBaaSPlatformSpec defines the desired state of DBaaSPlatform
@@ -64,6 +79,255 @@ type DBaaSPlatformSpec struct {
 	// +kubebuilder:validation:Maximum=1440
 	// The SyncPeriod set The minimum interval at which the provider operator controllers reconcile, the default value is 180 minutes.
 	SyncPeriod *int `json:"syncPeriod,omitempty"`
+
+	// Installation settings of the provider operators, by provider platform name
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Providers []DBaaSPlatformProvider `json:"providers,omitempty"`
+
+	// Deployment settings of the console plugins, by console plugin platform name
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ConsolePlugins []DBaaSPlatformConsolePlugin `json:"consolePlugins,omitempty"`
+
+	// Settings of the console telemetry plugin, sending console analytics
+	// +optional
+	Telemetry *DBaaSPlatformTelemetry `json:"telemetry,omitempty"`
+
+	// Settings of the console quick starts
+	// +optional
+	QuickStarts *DBaaSPlatformQuickStarts `json:"quickStarts,omitempty"`
+
+	// Overrides of the images deployed by the operator, such as mirrored images for disconnected clusters
+	// +optional
+	Images *DBaaSPlatformImages `json:"images,omitempty"`
+
+	// The pull policy of the images deployed by the operator. By default, the console plugin images are
+	// always pulled, and the connection binding image is pulled if not present.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Set to uninstall all the platforms, the console plugins, quick starts and provider operators
+	// +optional
+	Uninstall *DBaaSPlatformUninstall `json:"uninstall,omitempty"`
+}
+
+// DBaaSPlatformUninstall defines how the platforms are uninstalled
+type DBaaSPlatformUninstall struct {
+	// Purge first deletes the DBaaSConnections, DBaaSInstances and DBaaSInventories of all namespaces, with the provider
+	// custom resources they own, then uninstalls the provider operators and deletes their CRDs. Without purge, the
+	// providers with remaining inventories are not uninstalled.
+	// +optional
+	Purge bool `json:"purge,omitempty"`
+}
+
+// DBaaSPlatformConsolePlugin defines how a console plugin is deployed
+type DBaaSPlatformConsolePlugin struct {
+	// The name of the console plugin platform, such as dbaas-dynamic-plugin
+	// +kubebuilder:validation:Enum=dbaas-dynamic-plugin;console-telemetry-plugin
+	Name PlatformsName `json:"name"`
+
+	// The number of replicas of the console plugin, 3 by default.
+	// A PodDisruptionBudget keeps one replica available if there are multiple replicas.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// The compute resources of the console plugin container
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// The node selector of the console plugin pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the console plugin pods
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// The affinity of the console plugin pods
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+
+	// The priority class of the console plugin pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// GetConsolePlugin returns the deployment settings of a console plugin platform, or nil if it has none
+func (spec *DBaaSPlatformSpec) GetConsolePlugin(name PlatformsName) *DBaaSPlatformConsolePlugin {
+	for i := range spec.ConsolePlugins {
+		if spec.ConsolePlugins[i].Name == name {
+			return &spec.ConsolePlugins[i]
+		}
+	}
+	return nil
+}
+
+// DBaaSPlatformTelemetry defines the settings of the console telemetry plugin
+type DBaaSPlatformTelemetry struct {
+	// Disables the console telemetry plugin, and removes it from the console if it was installed
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// The key of a Secret in the DBaaSPlatform namespace holding the Segment key of the console telemetry plugin,
+	// instead of the default key
+	// +optional
+	SegmentKeyRef *v1.SecretKeySelector `json:"segmentKeyRef,omitempty"`
+}
+
+// DBaaSPlatformQuickStarts defines the settings of the console quick starts. Besides the built-in quick starts,
+// the operator installs the quick starts of the ConfigMaps of its namespace labelled with dbaas.redhat.com/quickstart,
+// as localized variants when the ConfigMap is annotated with dbaas.redhat.com/quickstart-language. The operator stops
+// updating an installed quick start annotated with dbaas.redhat.com/managed: "false".
+type DBaaSPlatformQuickStarts struct {
+	// The names of the built-in quick starts not to install
+	// +optional
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// DBaaSPlatformImages defines the images deployed by the operator
+type DBaaSPlatformImages struct {
+	// The catalog image of the Crunchy Bridge operator
+	// +optional
+	CrunchyBridgeCatalog string `json:"crunchyBridgeCatalog,omitempty"`
+
+	// The catalog image of the MongoDB Atlas operator
+	// +optional
+	MongoDBAtlasCatalog string `json:"mongodbAtlasCatalog,omitempty"`
+
+	// The catalog image of the CockroachDB Cloud operator
+	// +optional
+	CockroachDBCatalog string `json:"cockroachdbCatalog,omitempty"`
+
+	// The image of the DBaaS console plugin
+	// +optional
+	DBaaSDynamicPlugin string `json:"dbaasDynamicPlugin,omitempty"`
+
+	// The image of the console telemetry plugin
+	// +optional
+	ConsoleTelemetryPlugin string `json:"consoleTelemetryPlugin,omitempty"`
+
+	// The image of the deployments representing connections in the developer topology view
+	// +optional
+	ConnectionBinding string `json:"connectionBinding,omitempty"`
+}
+
+// GetImage returns the image override of a platform, or an empty string if it has none
+func (images *DBaaSPlatformImages) GetImage(platform PlatformsName) string {
+	if images == nil {
+		return ""
+	}
+	switch platform {
+	case CrunchyBridgeInstallation:
+		return images.CrunchyBridgeCatalog
+	case MongoDBAtlasInstallation:
+		return images.MongoDBAtlasCatalog
+	case CockroachDBInstallation:
+		return images.CockroachDBCatalog
+	case DBaaSDynamicPluginInstallation:
+		return images.DBaaSDynamicPlugin
+	case ConsoleTelemetryPluginInstallation:
+		return images.ConsoleTelemetryPlugin
+	}
+	return ""
+}
+
+// DBaaSPlatformProvider defines how a provider operator is installed and upgraded
+type DBaaSPlatformProvider struct {
+	// The name of the provider platform, such as mongodb-atlas
+	// +kubebuilder:validation:Enum=crunchy-bridge;mongodb-atlas;cockroachdb-cloud
+	Name PlatformsName `json:"name"`
+
+	// The approval mode of the provider operator upgrades, Automatic by default.
+	// With the Manual mode, the DBaaS operator only approves the initial installation, or the upgrades up to the pinned CSV.
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +optional
+	InstallPlanApproval string `json:"installPlanApproval,omitempty"`
+
+	// The subscription channel of the provider operator, overrides the default channel
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// The CSV of the provider operator version to pin, such as mongodb-atlas-kubernetes.v0.2.1.
+	// Pinning a version implies the Manual approval mode.
+	// +optional
+	CSV string `json:"csv,omitempty"`
+
+	// A ConfigMap in the DBaaSPlatform namespace holding the manifests of the provider operator, installed instead of
+	// the OLM subscription on clusters without OLM. Each key holds a stream of YAML documents, such as the CRDs and CSV
+	// of an operator bundle, or plain Deployment and RBAC manifests.
+	// +optional
+	ManifestsRef *v1.LocalObjectReference `json:"manifestsRef,omitempty"`
+
+	// Set to uninstall the provider operator, once the inventories of the provider are deleted
+	// +optional
+	Uninstall bool `json:"uninstall,omitempty"`
+
+	// Set to take over the management of a subscription of the provider operator package created by an admin in the
+	// DBaaSPlatform namespace. Otherwise, an existing subscription of the package is used as is, and left installed
+	// on uninstallation.
+	// +optional
+	AdoptSubscription bool `json:"adoptSubscription,omitempty"`
+
+	// The minimum interval in minutes at which the provider operator controllers reconcile, overrides the SyncPeriod
+	// of the platform
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	// +optional
+	SyncPeriod *int `json:"syncPeriod,omitempty"`
+
+	// Additional environment variables of the provider operator containers, SYNC_PERIOD_MIN is set by the syncPeriod
+	// +optional
+	Env []v1.EnvVar `json:"env,omitempty"`
+
+	// The compute resources of the provider operator containers
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// The node selector of the provider operator pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the provider operator pods
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+}
+
+// GetSyncPeriod returns the sync period of a provider operator, the provider setting overriding the platform one,
+// or nil if neither is set
+func (spec *DBaaSPlatformSpec) GetSyncPeriod(name PlatformsName) *int {
+	if provider := spec.GetProvider(name); provider != nil && provider.SyncPeriod != nil {
+		return provider.SyncPeriod
+	}
+	return spec.SyncPeriod
+}
+
+// GetProvider returns the installation settings of a provider platform, or nil if it has none
+func (spec *DBaaSPlatformSpec) GetProvider(name PlatformsName) *DBaaSPlatformProvider {
+	for i := range spec.Providers {
+		if spec.Providers[i].Name == name {
+			return &spec.Providers[i]
+		}
+	}
+	return nil
+}
+
+// IsUninstalled returns whether a platform is to be uninstalled
+func (spec *DBaaSPlatformSpec) IsUninstalled(name PlatformsName) bool {
+	if spec.Uninstall != nil {
+		return true
+	}
+	provider := spec.GetProvider(name)
+	return provider != nil && provider.Uninstall
+}
+
+// IsPurged returns whether the platforms are uninstalled with their data
+func (spec *DBaaSPlatformSpec) IsPurged() bool {
+	return spec.Uninstall != nil && spec.Uninstall.Purge
 }
 
 // DBaaSPlatformStatus defines the observed state of DBaaSPlatform
@@ -71,6 +335,91 @@ type DBaaSPlatformStatus struct {
 	PlatformName   PlatformsName         `json:"platformName"`
 	PlatformStatus PlatformsInstlnStatus `json:"platformStatus"`
 	LastMessage    string                `json:"lastMessage,omitempty"`
+
+	// The installed versions of the provider operators
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Providers []DBaaSPlatformProviderStatus `json:"providers,omitempty"`
+
+	// The platforms not installed, as the APIs they depend on are not available on the cluster
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Skipped []DBaaSPlatformSkipped `json:"skipped,omitempty"`
+
+	// Conditions showing whether the installed platform components are degraded
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The progress of the uninstallation of the platforms
+	// +optional
+	Uninstall *DBaaSPlatformUninstallStatus `json:"uninstall,omitempty"`
+}
+
+// DBaaSPlatformUninstallStatus reports the progress of the uninstallation of the platforms
+type DBaaSPlatformUninstallStatus struct {
+	// The current step of the uninstallation: DeletingConnections, DeletingInstances, DeletingInventories,
+	// UninstallingPlatforms or Complete
+	Phase UninstallPhase `json:"phase"`
+
+	// The number of objects remaining to delete in the current step
+	// +optional
+	Remaining int `json:"remaining,omitempty"`
+}
+
+// DBaaSPlatformSkipped reports a platform which is not installed
+type DBaaSPlatformSkipped struct {
+	// The name of the platform
+	Name PlatformsName `json:"name"`
+
+	// The reason the platform is not installed
+	Reason string `json:"reason"`
+}
+
+// DBaaSPlatformProviderStatus reports the installed version of a provider operator
+type DBaaSPlatformProviderStatus struct {
+	// The name of the provider platform
+	Name PlatformsName `json:"name"`
+
+	// The CSV of the installed provider operator version
+	InstalledCSV string `json:"installedCSV,omitempty"`
+
+	// The CSV of an upgrade waiting for approval
+	PendingCSV string `json:"pendingCSV,omitempty"`
+
+	// The reason the uninstallation of the provider operator is blocked, such as its remaining inventories
+	UninstallBlocked string `json:"uninstallBlocked,omitempty"`
+
+	// The subscription of a provider operator installed by an admin, used as is by the DBaaS operator
+	ExternalSubscription string `json:"externalSubscription,omitempty"`
+
+	// Why the existing subscriptions of the provider operator package conflict with the provider settings
+	Conflict string `json:"conflict,omitempty"`
+
+	// Why the pending install plans of the provider operator are not approved, such as a pinned CSV older than the
+	// installed CSV, or an install plan also installing the operators of other packages
+	ApprovalBlocked string `json:"approvalBlocked,omitempty"`
+}
+
+// SetProviderStatus adds or replaces the status of a provider platform
+func (status *DBaaSPlatformStatus) SetProviderStatus(providerStatus DBaaSPlatformProviderStatus) {
+	for i := range status.Providers {
+		if status.Providers[i].Name == providerStatus.Name {
+			status.Providers[i] = providerStatus
+			return
+		}
+	}
+	status.Providers = append(status.Providers, providerStatus)
+}
+
+// RemoveProviderStatus removes the status of an uninstalled provider platform
+func (status *DBaaSPlatformStatus) RemoveProviderStatus(name PlatformsName) {
+	for i := range status.Providers {
+		if status.Providers[i].Name == name {
+			status.Providers = append(status.Providers[:i], status.Providers[i+1:]...)
+			return
+		}
+	}
 }
 
 //+kubebuilder:object:root=true
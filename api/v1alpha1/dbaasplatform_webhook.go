@@ -0,0 +1,176 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasplatformlog = logf.Log.WithName("dbaasplatform-resource")
+
+func (r *DBaaSPlatform) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasplatform,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasplatforms,verbs=create;update,versions=v1alpha1,name=vdbaasplatform.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &DBaaSPlatform{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSPlatform) ValidateCreate() error {
+	dbaasplatformlog.Info("validate create", "name", r.Name)
+	return r.validatePlatform()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSPlatform) ValidateUpdate(old runtime.Object) error {
+	dbaasplatformlog.Info("validate update", "name", r.Name)
+	return r.validatePlatform()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSPlatform) ValidateDelete() error {
+	dbaasplatformlog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *DBaaSPlatform) validatePlatform() error {
+	specPath := field.NewPath("spec")
+
+	providerNames := map[PlatformsName]bool{}
+	for i, provider := range r.Spec.Providers {
+		providerPath := specPath.Child("providers").Index(i)
+		if providerNames[provider.Name] {
+			return field.Duplicate(providerPath.Child("name"), provider.Name)
+		}
+		providerNames[provider.Name] = true
+		if err := validateProviderEnv(providerPath.Child("env"), provider.Env); err != nil {
+			return err
+		}
+		if err := validatePodSettings(providerPath, provider.Resources, provider.NodeSelector, provider.Tolerations); err != nil {
+			return err
+		}
+	}
+
+	consolePluginNames := map[PlatformsName]bool{}
+	for i, consolePlugin := range r.Spec.ConsolePlugins {
+		consolePluginPath := specPath.Child("consolePlugins").Index(i)
+		if consolePluginNames[consolePlugin.Name] {
+			return field.Duplicate(consolePluginPath.Child("name"), consolePlugin.Name)
+		}
+		consolePluginNames[consolePlugin.Name] = true
+		if err := validatePodSettings(consolePluginPath, consolePlugin.Resources, consolePlugin.NodeSelector, consolePlugin.Tolerations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checks that the environment variables of a provider operator have unique valid names, and don't set the sync period
+func validateProviderEnv(path *field.Path, envs []corev1.EnvVar) error {
+	envNames := map[string]bool{}
+	for i, env := range envs {
+		namePath := path.Index(i).Child("name")
+		if len(env.Name) == 0 {
+			return field.Required(namePath, "environment variable names must not be empty")
+		}
+		if errs := validation.IsEnvVarName(env.Name); len(errs) > 0 {
+			return field.Invalid(namePath, env.Name, strings.Join(errs, "; "))
+		}
+		if env.Name == SyncPeriodEnvName {
+			return field.Forbidden(namePath, fmt.Sprintf("%s is set by the syncPeriod", SyncPeriodEnvName))
+		}
+		if envNames[env.Name] {
+			return field.Duplicate(namePath, env.Name)
+		}
+		envNames[env.Name] = true
+	}
+	return nil
+}
+
+// checks the compute resources, node selector and tolerations of the pods of a platform
+func validatePodSettings(path *field.Path, resources *corev1.ResourceRequirements, nodeSelector map[string]string, tolerations []corev1.Toleration) error {
+	if resources != nil {
+		for name, request := range resources.Requests {
+			if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				return field.Invalid(path.Child("resources").Child("requests").Key(string(name)), request.String(),
+					fmt.Sprintf("must be less than or equal to the %s limit %s", name, limit.String()))
+			}
+		}
+	}
+
+	if errs := metav1validation.ValidateLabels(nodeSelector, path.Child("nodeSelector")); len(errs) > 0 {
+		return errs[0]
+	}
+
+	for i, toleration := range tolerations {
+		if err := validateToleration(path.Child("tolerations").Index(i), toleration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checks a toleration the way the API server checks the tolerations of pods
+func validateToleration(path *field.Path, toleration corev1.Toleration) error {
+	if len(toleration.Key) > 0 {
+		if errs := validation.IsQualifiedName(toleration.Key); len(errs) > 0 {
+			return field.Invalid(path.Child("key"), toleration.Key, strings.Join(errs, "; "))
+		}
+	}
+
+	switch toleration.Operator {
+	case corev1.TolerationOpEqual, "":
+		if len(toleration.Key) == 0 {
+			return field.Invalid(path.Child("operator"), toleration.Operator, "operator must be Exists when the key is empty")
+		}
+		if errs := validation.IsValidLabelValue(toleration.Value); len(errs) > 0 {
+			return field.Invalid(path.Child("value"), toleration.Value, strings.Join(errs, "; "))
+		}
+	case corev1.TolerationOpExists:
+		if len(toleration.Value) > 0 {
+			return field.Invalid(path.Child("value"), toleration.Value, "value must be empty when the operator is Exists")
+		}
+	default:
+		return field.NotSupported(path.Child("operator"), toleration.Operator,
+			[]string{string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)})
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return field.NotSupported(path.Child("effect"), toleration.Effect,
+			[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)})
+	}
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		return field.Invalid(path.Child("effect"), toleration.Effect, "effect must be NoExecute when tolerationSeconds is set")
+	}
+	return nil
+}
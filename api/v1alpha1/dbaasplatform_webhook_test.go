@@ -0,0 +1,149 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("DBaaSPlatform Webhook", func() {
+	Context("nominal", func() {
+		platform := &DBaaSPlatform{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-valid-platform",
+				Namespace: testNamespace,
+			},
+			Spec: DBaaSPlatformSpec{
+				Providers: []DBaaSPlatformProvider{
+					{
+						Name:         MongoDBAtlasInstallation,
+						SyncPeriod:   pointer.Int(60),
+						Env:          []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+						NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+						Tolerations:  []corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+					},
+				},
+			},
+		}
+
+		AfterEach(assertResourceDeletion(platform))
+		It("should allow creating a platform with provider operator settings", func() {
+			Expect(k8sClient.Create(ctx, platform)).Should(Succeed())
+		})
+	})
+
+	Context("invalid platform", func() {
+		It("should not allow creating a platform setting the sync period of a provider in its env", func() {
+			platform := &DBaaSPlatform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sync-period-env-platform",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSPlatformSpec{
+					Providers: []DBaaSPlatformProvider{
+						{
+							Name: CrunchyBridgeInstallation,
+							Env:  []corev1.EnvVar{{Name: SyncPeriodEnvName, Value: "60"}},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, platform)).Should(MatchError("admission webhook \"vdbaasplatform.kb.io\" denied the request: " +
+				"spec.providers[0].env[0].name: Forbidden: SYNC_PERIOD_MIN is set by the syncPeriod"))
+		})
+
+		It("should not allow creating a platform setting a provider twice", func() {
+			platform := &DBaaSPlatform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-duplicate-provider-platform",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSPlatformSpec{
+					Providers: []DBaaSPlatformProvider{
+						{Name: CrunchyBridgeInstallation, SyncPeriod: pointer.Int(60)},
+						{Name: CrunchyBridgeInstallation, SyncPeriod: pointer.Int(120)},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, platform)).Should(MatchError("admission webhook \"vdbaasplatform.kb.io\" denied the request: " +
+				"spec.providers[1].name: Duplicate value: \"crunchy-bridge\""))
+		})
+	})
+})
+
+func TestValidatePlatform(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	envPath := field.NewPath("spec").Child("providers").Index(0).Child("env")
+	Expect(validateProviderEnv(envPath, []corev1.EnvVar{{Name: "LOG_LEVEL"}, {Name: "WATCH_NAMESPACE"}})).To(Succeed())
+	Expect(validateProviderEnv(envPath, []corev1.EnvVar{{Name: "LOG_LEVEL"}, {Name: "LOG_LEVEL"}})).To(MatchError(
+		"spec.providers[0].env[1].name: Duplicate value: \"LOG_LEVEL\""))
+	Expect(validateProviderEnv(envPath, []corev1.EnvVar{{Name: ""}})).To(MatchError(
+		"spec.providers[0].env[0].name: Required value: environment variable names must not be empty"))
+	Expect(validateProviderEnv(envPath, []corev1.EnvVar{{Name: "1LOG"}})).To(HaveOccurred())
+
+	// the providers and console plugins are set once per name
+	platform := &DBaaSPlatform{
+		Spec: DBaaSPlatformSpec{
+			Providers: []DBaaSPlatformProvider{{Name: MongoDBAtlasInstallation}, {Name: CrunchyBridgeInstallation}},
+			ConsolePlugins: []DBaaSPlatformConsolePlugin{
+				{Name: DBaaSDynamicPluginInstallation},
+				{Name: ConsoleTelemetryPluginInstallation},
+			},
+		},
+	}
+	Expect(platform.validatePlatform()).To(Succeed())
+	platform.Spec.Providers = append(platform.Spec.Providers, DBaaSPlatformProvider{Name: MongoDBAtlasInstallation})
+	Expect(platform.validatePlatform()).To(MatchError(
+		"spec.providers[2].name: Duplicate value: \"mongodb-atlas\""))
+	platform.Spec.Providers = platform.Spec.Providers[:2]
+	platform.Spec.ConsolePlugins = append(platform.Spec.ConsolePlugins, DBaaSPlatformConsolePlugin{Name: DBaaSDynamicPluginInstallation})
+	Expect(platform.validatePlatform()).To(MatchError(
+		"spec.consolePlugins[2].name: Duplicate value: \"dbaas-dynamic-plugin\""))
+
+	path := field.NewPath("spec").Child("providers").Index(0)
+	Expect(validatePodSettings(path, &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}, nil, nil)).To(Succeed())
+	Expect(validatePodSettings(path, &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+	}, nil, nil)).To(MatchError(
+		"spec.providers[0].resources.requests[cpu]: Invalid value: \"1\": must be less than or equal to the cpu limit 500m"))
+	Expect(validatePodSettings(path, nil, map[string]string{"invalid key!": "value"}, nil)).To(HaveOccurred())
+
+	// tolerations follow the pod rules
+	Expect(validatePodSettings(path, nil, nil, []corev1.Toleration{{Operator: corev1.TolerationOpExists}})).To(Succeed())
+	Expect(validatePodSettings(path, nil, nil, []corev1.Toleration{{Key: "dedicated", Value: "dbaas", Effect: corev1.TaintEffectNoSchedule}})).To(Succeed())
+	Expect(validatePodSettings(path, nil, nil, []corev1.Toleration{{Value: "dbaas"}})).To(MatchError(
+		"spec.providers[0].tolerations[0].operator: Invalid value: \"\": operator must be Exists when the key is empty"))
+	Expect(validatePodSettings(path, nil, nil, []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "dbaas"}})).To(MatchError(
+		"spec.providers[0].tolerations[0].value: Invalid value: \"dbaas\": value must be empty when the operator is Exists"))
+	Expect(validatePodSettings(path, nil, nil, []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, TolerationSeconds: pointer.Int64(60)}})).To(MatchError(
+		"spec.providers[0].tolerations[0].effect: Invalid value: \"\": effect must be NoExecute when tolerationSeconds is set"))
+}
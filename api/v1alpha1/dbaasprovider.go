@@ -29,6 +29,12 @@ const (
 	DBaaSConnectionProviderSyncType string = "ReadyForBinding"
 	DBaaSInstanceReadyType          string = "InstanceReady"
 	DBaaSInstanceProviderSyncType   string = "ProvisionReady"
+	DBaaSInstanceExpiringType       string = "Expiring"
+	DBaaSTenantReadyType            string = "Ready"
+	DBaaSTenantAuthzSyncedType      string = "AuthzSynced"
+	DBaaSTenantDegradedType         string = "Degraded"
+	DBaaSProviderWatchesReadyType   string = "WatchesReady"
+	DBaaSPlatformDegradedType       string = "Degraded"
 
 	// DBaaS condition reasons
 	Ready                       string = "Ready"
@@ -39,6 +45,13 @@ const (
 	DBaaSInvalidNamespace       string = "InvalidNamespace"
 	ProviderReconcileInprogress string = "ProviderReconcileInprogress"
 	ProviderParsingError        string = "ProviderParsingError"
+	AuthzSyncFailed             string = "AuthzSyncFailed"
+	AccessReviewFailed          string = "AccessReviewFailed"
+	NoReadyInventories          string = "NoReadyInventories"
+	ProviderWatchFailed         string = "ProviderWatchFailed"
+	ProvisioningNotSupported    string = "ProvisioningNotSupported"
+	PlatformDegraded            string = "PlatformDegraded"
+	NotDegraded                 string = "NotDegraded"
 
 	// DBaaS condition messages
 	MsgProviderCRStatusSyncDone      string = "Provider Custom Resource status sync completed"
@@ -46,6 +59,18 @@ const (
 	MsgInventoryNotReady             string = "Inventory discovery not done"
 	MsgTenantNotFound                string = "Failed to find DBaaS tenants"
 	MsgInvalidNamespace              string = "Invalid connection namespace for the referenced inventory"
+	MsgAuthzSynced                   string = "Tenant RBAC reconciled"
+	MsgTenantReady                   string = "Tenant RBAC reconciled and inventories ready"
+	MsgNoReadyInventories            string = "No ready DBaaS Inventories in the inventory namespace"
+	MsgAccessReviewFailed            string = "Access reviews failed, keeping the last known-good tenant RBAC"
+	MsgProviderWatchesReady          string = "Watching the provider inventory, connection and instance Custom Resources"
+	MsgProvisioningNotSupported      string = "The provider does not support provisioning instances"
+	MsgPlatformHealthy               string = "The installed platform components are healthy"
+	MsgTenantNotDegraded             string = "All the tenant access reviews and RBAC objects reconciled"
+
+	// DBaaS event and condition reasons of expiring instances
+	DBaaSInstanceExpiring string = "InstanceExpiring"
+	DBaaSInstanceExpired  string = "InstanceExpired"
 
 	TypeLabelValue    = "credentials"
 	TypeLabelKey      = "db-operator/type"
@@ -80,6 +105,75 @@ type DBaaSProviderSpec struct {
 
 	// InstanceParameterSpecs  indicates what parameters to collect from UX & how to display fields in a form in order to provision an instance
 	InstanceParameterSpecs []InstanceParameterSpec `json:"instanceParameterSpecs"`
+
+	// Capabilities indicates which operations the provider supports. If not set, provisioning and deletion of
+	// instances are supported, in any cloud provider and region, and other operations are not.
+	Capabilities *DBaaSProviderCapabilities `json:"capabilities,omitempty"`
+}
+
+// DBaaSProviderCapabilities indicates which operations a provider supports, so that unsupported operations are rejected
+type DBaaSProviderCapabilities struct {
+	// Indicates whether instances can be provisioned through DBaaSInstances, supported if not set
+	// +kubebuilder:default=true
+	Provisioning *bool `json:"provisioning,omitempty"`
+
+	// Indicates whether the provider deletes the instances of deleted DBaaSInstances, supported if not set
+	// +kubebuilder:default=true
+	Deletion *bool `json:"deletion,omitempty"`
+
+	// Indicates whether provisioned instances can be scaled. Declared only, not enforced by the operator, the
+	// provider status shows it to the console.
+	Scaling bool `json:"scaling,omitempty"`
+
+	// Indicates whether provisioned instances can be backed up. Declared only, not enforced by the operator, the
+	// provider status shows it to the console.
+	Backup bool `json:"backup,omitempty"`
+
+	// Indicates whether the credentials of provisioned instances can be rotated. Declared only, not enforced by the
+	// operator, the provider status shows it to the console.
+	CredentialRotation bool `json:"credentialRotation,omitempty"`
+
+	// Indicates whether provisioned instances can be cloned. Declared only, not enforced by the operator, the
+	// provider status shows it to the console.
+	Clone bool `json:"clone,omitempty"`
+
+	// The cloud providers, and their regions, where instances can be provisioned. Any cloud provider is supported if empty.
+	CloudProviders []DBaaSProviderCloudProvider `json:"cloudProviders,omitempty"`
+}
+
+// DBaaSProviderCloudProvider defines a cloud provider where instances can be provisioned
+type DBaaSProviderCloudProvider struct {
+	// The name of the cloud provider, as set in the cloudProvider field of DBaaSInstances
+	Name string `json:"name"`
+
+	// The regions of the cloud provider where instances can be provisioned. Any region is supported if empty.
+	Regions []string `json:"regions,omitempty"`
+}
+
+// GetCapabilities returns the provider capabilities, defaulting to provisioning and deletion of instances if not set
+func (s *DBaaSProviderSpec) GetCapabilities() DBaaSProviderCapabilities {
+	supported := true
+	capabilities := DBaaSProviderCapabilities{}
+	if s.Capabilities != nil {
+		capabilities = *s.Capabilities.DeepCopy()
+	}
+	if capabilities.Provisioning == nil {
+		capabilities.Provisioning = &supported
+	}
+	if capabilities.Deletion == nil {
+		capabilities.Deletion = &supported
+	}
+	return capabilities
+}
+
+// SupportsProvisioning returns whether instances can be provisioned through DBaaSInstances
+func (c DBaaSProviderCapabilities) SupportsProvisioning() bool {
+	return c.Provisioning == nil || *c.Provisioning
+}
+
+// SupportsDeletion returns whether the provider deletes the instances of deleted DBaaSInstances
+func (c DBaaSProviderCapabilities) SupportsDeletion() bool {
+	return c.Deletion == nil || *c.Deletion
 }
 
 type DatabaseProvider struct {
@@ -114,8 +208,64 @@ type CredentialField struct {
 
 	// If this field is required or not
 	Required bool `json:"required"`
+
+	// How to render and validate this field in forms
+	FieldMetadata `json:",inline"`
+}
+
+// FieldMetadata indicates how to render and validate a credential field or instance parameter in forms
+type FieldMetadata struct {
+	// Help text shown with the field
+	HelpText string `json:"helpText,omitempty"`
+
+	// Placeholder text shown while the field is empty
+	Placeholder string `json:"placeholder,omitempty"`
+
+	// The name of the group of fields the field is shown in
+	Group string `json:"group,omitempty"`
+
+	// The allowed values of the field, shown as a dropdown
+	Options []FieldOption `json:"options,omitempty"`
+
+	// The minimum value of an integer field
+	Minimum *int64 `json:"minimum,omitempty"`
+
+	// The maximum value of an integer field
+	Maximum *int64 `json:"maximum,omitempty"`
+
+	// A regular expression the whole value of a string field must match
+	Pattern string `json:"pattern,omitempty"`
+
+	// Shows the field, and requires it if set as required, only when another field has one of the given values
+	VisibleWhen *FieldCondition `json:"visibleWhen,omitempty"`
+}
+
+// FieldOption is an allowed value of a field
+type FieldOption struct {
+	// The value of the option
+	Value string `json:"value"`
+
+	// A user-friendly name for the option, the value is shown if not set
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// FieldCondition matches the value of another field of the same form
+type FieldCondition struct {
+	// The key of the credential field, or the name of the instance parameter, to match
+	Field string `json:"field"`
+
+	// The values matching the condition
+	Values []string `json:"values"`
 }
 
+// Types of credential fields and instance parameters
+const (
+	FieldTypeString       string = "string"
+	FieldTypeMaskedString string = "maskedstring"
+	FieldTypeInteger      string = "integer"
+	FieldTypeBoolean      string = "boolean"
+)
+
 // DBaaSInventorySpec defines the Inventory Spec to be used by provider operators
 type DBaaSInventorySpec struct {
 	// The Secret containing the provider-specific connection credentials to use with its API
@@ -154,6 +304,15 @@ type NamespacedName struct {
 	Name string `json:"name"`
 }
 
+// InventoryRefNamespace returns the namespace of a referenced inventory, defaulting to the namespace of the referencing
+// object
+func InventoryRefNamespace(inventoryRef NamespacedName, namespace string) string {
+	if len(inventoryRef.Namespace) == 0 {
+		return namespace
+	}
+	return inventoryRef.Namespace
+}
+
 // DBaaSConnectionSpec defines the desired state of DBaaSConnection
 type DBaaSConnectionSpec struct {
 	// A reference to the relevant DBaaSInventory CR
@@ -226,9 +385,21 @@ type DBaaSInstanceStatus struct {
 	// Deleting - cluster deletion in progress
 	// Deleted - cluster has been deleted
 	// Ready - cluster provisioning complete
+	// Failed - cluster provisioning failed
 	Phase string `json:"phase"`
 }
 
+// Provisioning phases of provider instances
+const (
+	InstancePhasePending  string = "Pending"
+	InstancePhaseCreating string = "Creating"
+	InstancePhaseUpdating string = "Updating"
+	InstancePhaseDeleting string = "Deleting"
+	InstancePhaseDeleted  string = "Deleted"
+	InstancePhaseReady    string = "Ready"
+	InstancePhaseFailed   string = "Failed"
+)
+
 // DBaaSProviderInstance is the schema for unmarshalling provider instance object
 type DBaaSProviderInstance struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -252,6 +423,9 @@ type InstanceParameterSpec struct {
 
 	// Default value for this field
 	DefaultValue string `json:"defaultValue,omitempty"`
+
+	// How to render and validate this field in forms
+	FieldMetadata `json:",inline"`
 }
 
 var InstanceParameterSpecs = InstanceParameterSpec{}
@@ -22,9 +22,16 @@ import (
 
 // DBaaSProviderStatus defines the observed state of DBaaSProvider
 type DBaaSProviderStatus struct {
+	// Conditions showing whether the provider inventory, connection and instance CRs are being watched
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The capabilities of the provider, including defaults when not declared
+	Capabilities *DBaaSProviderCapabilities `json:"capabilities,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Watching",type=string,JSONPath=`.status.conditions[?(@.type=="WatchesReady")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 
@@ -0,0 +1,304 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasproviderlog = logf.Log.WithName("dbaasprovider-resource")
+
+var providerWebhookApiClient client.Client
+
+// the types of credential fields and instance parameters supported by the console forms
+var supportedFieldTypes = []string{FieldTypeString, FieldTypeMaskedString, FieldTypeInteger, FieldTypeBoolean}
+
+// the icon media types supported by the console, following the CSV icon format
+var supportedIconMediaTypes = []string{"image/gif", "image/jpeg", "image/png", "image/svg+xml"}
+
+func (r *DBaaSProvider) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if providerWebhookApiClient == nil {
+		providerWebhookApiClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1alpha1-dbaasprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasproviders,verbs=create;update,versions=v1alpha1,name=vdbaasprovider.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &DBaaSProvider{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSProvider) ValidateCreate() error {
+	dbaasproviderlog.Info("validate create", "name", r.Name)
+	return r.validateProvider()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSProvider) ValidateUpdate(old runtime.Object) error {
+	dbaasproviderlog.Info("validate update", "name", r.Name)
+	return r.validateProvider()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSProvider) ValidateDelete() error {
+	dbaasproviderlog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *DBaaSProvider) validateProvider() error {
+	specPath := field.NewPath("spec")
+
+	// the provider CRs must be defined in the DBaaS group
+	if err := validateProviderKind(specPath.Child("inventoryKind"), r.Spec.InventoryKind); err != nil {
+		return err
+	}
+	if err := validateProviderKind(specPath.Child("connectionKind"), r.Spec.ConnectionKind); err != nil {
+		return err
+	}
+	if err := validateProviderKind(specPath.Child("instanceKind"), r.Spec.InstanceKind); err != nil {
+		return err
+	}
+
+	credentialKeys := map[string]bool{}
+	for i, credentialField := range r.Spec.CredentialFields {
+		fieldPath := specPath.Child("credentialFields").Index(i)
+		if len(credentialField.Key) == 0 {
+			return field.Required(fieldPath.Child("key"), "credential field keys must not be empty")
+		}
+		if credentialKeys[credentialField.Key] {
+			return field.Duplicate(fieldPath.Child("key"), credentialField.Key)
+		}
+		credentialKeys[credentialField.Key] = true
+		if err := validateFieldType(fieldPath.Child("type"), credentialField.Type); err != nil {
+			return err
+		}
+	}
+
+	parameterNames := map[string]bool{}
+	for i, parameterSpec := range r.Spec.InstanceParameterSpecs {
+		parameterPath := specPath.Child("instanceParameterSpecs").Index(i)
+		if len(parameterSpec.Name) == 0 {
+			return field.Required(parameterPath.Child("name"), "instance parameter names must not be empty")
+		}
+		if parameterNames[parameterSpec.Name] {
+			return field.Duplicate(parameterPath.Child("name"), parameterSpec.Name)
+		}
+		parameterNames[parameterSpec.Name] = true
+		if err := validateFieldType(parameterPath.Child("type"), parameterSpec.Type); err != nil {
+			return err
+		}
+	}
+
+	for i, credentialField := range r.Spec.CredentialFields {
+		fieldPath := specPath.Child("credentialFields").Index(i)
+		if err := validateFieldMetadata(fieldPath, credentialField.Key, credentialField.Type, credentialField.FieldMetadata, credentialKeys); err != nil {
+			return err
+		}
+	}
+	for i, parameterSpec := range r.Spec.InstanceParameterSpecs {
+		parameterPath := specPath.Child("instanceParameterSpecs").Index(i)
+		if err := validateFieldMetadata(parameterPath, parameterSpec.Name, parameterSpec.Type, parameterSpec.FieldMetadata, parameterNames); err != nil {
+			return err
+		}
+		if len(parameterSpec.DefaultValue) > 0 {
+			if err := validateFieldValue(parameterSpec.Type, parameterSpec.FieldMetadata, parameterSpec.DefaultValue); err != nil {
+				return field.Invalid(parameterPath.Child("defaultValue"), parameterSpec.DefaultValue, fmt.Sprintf("the default value %v", err))
+			}
+		}
+	}
+
+	if err := validateProviderCapabilities(specPath.Child("capabilities"), r.Spec.Capabilities); err != nil {
+		return err
+	}
+
+	return validateProviderIcon(specPath.Child("provider").Child("icon"), r.Spec.Provider.Icon)
+}
+
+// checks that the cloud providers of the capabilities have unique names
+func validateProviderCapabilities(path *field.Path, capabilities *DBaaSProviderCapabilities) error {
+	if capabilities == nil {
+		return nil
+	}
+	cloudProviderNames := map[string]bool{}
+	for i, cloudProvider := range capabilities.CloudProviders {
+		cloudProviderPath := path.Child("cloudProviders").Index(i)
+		if len(cloudProvider.Name) == 0 {
+			return field.Required(cloudProviderPath.Child("name"), "cloud provider names must not be empty")
+		}
+		if cloudProviderNames[cloudProvider.Name] {
+			return field.Duplicate(cloudProviderPath.Child("name"), cloudProvider.Name)
+		}
+		cloudProviderNames[cloudProvider.Name] = true
+	}
+	return nil
+}
+
+// checks that a provider kind is defined by a CRD in the DBaaS group
+func validateProviderKind(path *field.Path, kind string) error {
+	if len(kind) == 0 {
+		return field.Required(path, "the provider resource kind must be set")
+	}
+	gk := schema.GroupKind{Group: GroupVersion.Group, Kind: kind}
+	if _, err := providerWebhookApiClient.RESTMapper().RESTMapping(gk, GroupVersion.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return field.Invalid(path, kind, fmt.Sprintf("no CRD found for kind %s in the %s group", kind, GroupVersion.String()))
+		}
+		return err
+	}
+	return nil
+}
+
+// checks that a credential field or instance parameter type is supported, regardless of case
+func validateFieldType(path *field.Path, fieldType string) error {
+	for _, supportedType := range supportedFieldTypes {
+		if strings.EqualFold(fieldType, supportedType) {
+			return nil
+		}
+	}
+	return field.NotSupported(path, fieldType, supportedFieldTypes)
+}
+
+// checks that the form metadata of a credential field or instance parameter applies to its type, and that its
+// visibility depends on another field of the same form
+func validateFieldMetadata(path *field.Path, name string, fieldType string, metadata FieldMetadata, formFields map[string]bool) error {
+	optionValues := map[string]bool{}
+	for i, option := range metadata.Options {
+		optionPath := path.Child("options").Index(i)
+		if len(option.Value) == 0 {
+			return field.Required(optionPath.Child("value"), "option values must not be empty")
+		}
+		if optionValues[option.Value] {
+			return field.Duplicate(optionPath.Child("value"), option.Value)
+		}
+		optionValues[option.Value] = true
+		if err := validateFieldValue(fieldType, FieldMetadata{}, option.Value); err != nil {
+			return field.Invalid(optionPath.Child("value"), option.Value, fmt.Sprintf("the option value %v", err))
+		}
+	}
+
+	if !strings.EqualFold(fieldType, FieldTypeInteger) {
+		if metadata.Minimum != nil {
+			return field.Forbidden(path.Child("minimum"), "minimum only applies to integer fields")
+		}
+		if metadata.Maximum != nil {
+			return field.Forbidden(path.Child("maximum"), "maximum only applies to integer fields")
+		}
+	} else if metadata.Minimum != nil && metadata.Maximum != nil && *metadata.Minimum > *metadata.Maximum {
+		return field.Invalid(path.Child("maximum"), *metadata.Maximum, "maximum must not be less than minimum")
+	}
+
+	if len(metadata.Pattern) > 0 {
+		if !strings.EqualFold(fieldType, FieldTypeString) && !strings.EqualFold(fieldType, FieldTypeMaskedString) {
+			return field.Forbidden(path.Child("pattern"), "pattern only applies to string fields")
+		}
+		if _, err := regexp.Compile(metadata.Pattern); err != nil {
+			return field.Invalid(path.Child("pattern"), metadata.Pattern, fmt.Sprintf("the pattern is not a valid regular expression: %v", err))
+		}
+	}
+
+	if metadata.VisibleWhen != nil {
+		conditionPath := path.Child("visibleWhen")
+		if metadata.VisibleWhen.Field == name || !formFields[metadata.VisibleWhen.Field] {
+			return field.Invalid(conditionPath.Child("field"), metadata.VisibleWhen.Field, "the field must be another field of the same form")
+		}
+		if len(metadata.VisibleWhen.Values) == 0 {
+			return field.Required(conditionPath.Child("values"), "the values showing the field must be set")
+		}
+	}
+	return nil
+}
+
+// checks that the value of a credential field or instance parameter is valid for its type and form metadata
+func validateFieldValue(fieldType string, metadata FieldMetadata, value string) error {
+	switch strings.ToLower(fieldType) {
+	case FieldTypeInteger:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		if metadata.Minimum != nil && n < *metadata.Minimum {
+			return fmt.Errorf("must be at least %d", *metadata.Minimum)
+		}
+		if metadata.Maximum != nil && n > *metadata.Maximum {
+			return fmt.Errorf("must be at most %d", *metadata.Maximum)
+		}
+	case FieldTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+	case FieldTypeString, FieldTypeMaskedString:
+		if len(metadata.Pattern) > 0 {
+			if matched, err := regexp.MatchString("^(?:"+metadata.Pattern+")$", value); err != nil || !matched {
+				return fmt.Errorf("must match the pattern %s", metadata.Pattern)
+			}
+		}
+	}
+
+	if len(metadata.Options) > 0 {
+		var optionValues []string
+		for _, option := range metadata.Options {
+			if option.Value == value {
+				return nil
+			}
+			optionValues = append(optionValues, option.Value)
+		}
+		return fmt.Errorf("must be one of: %s", strings.Join(optionValues, ", "))
+	}
+	return nil
+}
+
+// returns whether a credential field or instance parameter is shown, given the values of the other fields of the form
+func isFieldVisible(metadata FieldMetadata, values map[string]string) bool {
+	if metadata.VisibleWhen == nil {
+		return true
+	}
+	value, ok := values[metadata.VisibleWhen.Field]
+	return ok && containsString(metadata.VisibleWhen.Values, value)
+}
+
+// checks that the icon, when set, has a supported media type and a base64 encoded payload
+func validateProviderIcon(path *field.Path, icon ProviderIcon) error {
+	if len(icon.Data) == 0 && len(icon.MediaType) == 0 {
+		return nil
+	}
+	if !containsString(supportedIconMediaTypes, icon.MediaType) {
+		return field.NotSupported(path.Child("mediatype"), icon.MediaType, supportedIconMediaTypes)
+	}
+	if len(icon.Data) == 0 {
+		return field.Required(path.Child("base64data"), "the icon data must be set along with its media type")
+	}
+	if _, err := base64.StdEncoding.DecodeString(icon.Data); err != nil {
+		return field.Invalid(path.Child("base64data"), "<icon data>", fmt.Sprintf("the icon data is not valid base64: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("DBaaSProvider Webhook", func() {
+	Context("nominal", func() {
+		provider := testProvider.DeepCopy()
+		provider.Name = "test-valid-provider"
+		provider.Spec.Provider.Icon = ProviderIcon{
+			Data:      "iVBORw0KGgo=",
+			MediaType: "image/png",
+		}
+
+		AfterEach(assertResourceDeletion(provider))
+		It("should allow creating a provider referencing existing CRDs", func() {
+			Expect(k8sClient.Create(ctx, provider)).Should(Succeed())
+		})
+	})
+
+	Context("invalid provider", func() {
+		It("should not allow creating a provider referencing an unknown kind", func() {
+			provider := testProvider.DeepCopy()
+			provider.Name = "test-unknown-kind-provider"
+			provider.Spec.InventoryKind = "MongoDBAtlasInventoryTypo"
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.inventoryKind: Invalid value: \"MongoDBAtlasInventoryTypo\": no CRD found for kind MongoDBAtlasInventoryTypo in the dbaas.redhat.com/v1alpha1 group"))
+		})
+		It("should not allow creating a provider with duplicate credential field keys", func() {
+			provider := testProvider.DeepCopy()
+			provider.Name = "test-duplicate-key-provider"
+			provider.Spec.CredentialFields[1].Key = provider.Spec.CredentialFields[0].Key
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.credentialFields[1].key: Duplicate value: \"field1\""))
+		})
+		It("should not allow creating a provider with unknown instance parameter types", func() {
+			provider := testProvider.DeepCopy()
+			provider.Name = "test-unknown-type-provider"
+			provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{
+				{Name: "name", Type: "text"},
+			}
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.instanceParameterSpecs[0].type: Unsupported value: \"text\": supported values: \"string\", \"maskedstring\", \"integer\", \"boolean\""))
+		})
+		It("should not allow creating a provider with an invalid icon", func() {
+			provider := testProvider.DeepCopy()
+			provider.Name = "test-invalid-icon-provider"
+			provider.Spec.Provider.Icon = ProviderIcon{
+				Data:      "not base64!",
+				MediaType: "image/png",
+			}
+			err := k8sClient.Create(ctx, provider)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("spec.provider.icon.base64data: Invalid value: \"<icon data>\": the icon data is not valid base64"))
+
+			provider.Spec.Provider.Icon = ProviderIcon{
+				Data:      "iVBORw0KGgo=",
+				MediaType: "image/bmp",
+			}
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.provider.icon.mediatype: Unsupported value: \"image/bmp\": supported values: \"image/gif\", \"image/jpeg\", \"image/png\", \"image/svg+xml\""))
+		})
+		It("should not allow creating a provider with duplicate cloud providers", func() {
+			provider := testProvider.DeepCopy()
+			provider.Name = "test-duplicate-cloud-provider"
+			provider.Spec.Capabilities = &DBaaSProviderCapabilities{
+				Provisioning: pointer.Bool(true),
+				Deletion:     pointer.Bool(true),
+				CloudProviders: []DBaaSProviderCloudProvider{
+					{Name: "AWS"},
+					{Name: "AWS"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.capabilities.cloudProviders[1].name: Duplicate value: \"AWS\""))
+		})
+		It("should not allow creating a provider with form metadata not applying to the field type", func() {
+			provider := testProvider.DeepCopy()
+			provider.Name = "test-invalid-metadata-provider"
+			provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{
+				{Name: "size", Type: FieldTypeInteger, FieldMetadata: FieldMetadata{Pattern: "[0-9]+"}},
+			}
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.instanceParameterSpecs[0].pattern: Forbidden: pattern only applies to string fields"))
+
+			provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{
+				{Name: "size", Type: FieldTypeString, DefaultValue: "M5", FieldMetadata: FieldMetadata{Options: []FieldOption{{Value: "M0"}, {Value: "M10"}}}},
+			}
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.instanceParameterSpecs[0].defaultValue: Invalid value: \"M5\": the default value must be one of: M0, M10"))
+
+			provider.Spec.InstanceParameterSpecs = []InstanceParameterSpec{
+				{Name: "size", Type: FieldTypeString, FieldMetadata: FieldMetadata{VisibleWhen: &FieldCondition{Field: "tier", Values: []string{"dedicated"}}}},
+			}
+			Expect(k8sClient.Create(ctx, provider)).Should(MatchError("admission webhook \"vdbaasprovider.kb.io\" denied the request: " +
+				"spec.instanceParameterSpecs[0].visibleWhen.field: Invalid value: \"tier\": the field must be another field of the same form"))
+		})
+	})
+})
+
+func TestValidateFieldValue(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	Expect(validateFieldValue("Integer", FieldMetadata{Minimum: pointer.Int64(1), Maximum: pointer.Int64(3)}, "2")).To(Succeed())
+	Expect(validateFieldValue(FieldTypeInteger, FieldMetadata{}, "two")).To(MatchError("must be an integer"))
+	Expect(validateFieldValue(FieldTypeInteger, FieldMetadata{Minimum: pointer.Int64(1)}, "0")).To(MatchError("must be at least 1"))
+	Expect(validateFieldValue(FieldTypeInteger, FieldMetadata{Maximum: pointer.Int64(3)}, "4")).To(MatchError("must be at most 3"))
+	Expect(validateFieldValue(FieldTypeBoolean, FieldMetadata{}, "yes")).To(MatchError("must be true or false"))
+
+	// patterns match whole values
+	Expect(validateFieldValue(FieldTypeMaskedString, FieldMetadata{Pattern: "[a-z]+"}, "secret")).To(Succeed())
+	Expect(validateFieldValue(FieldTypeMaskedString, FieldMetadata{Pattern: "[a-z]+"}, "secret1")).To(MatchError("must match the pattern [a-z]+"))
+
+	options := FieldMetadata{Options: []FieldOption{{Value: "AWS", DisplayName: "Amazon Web Services"}, {Value: "GCP"}}}
+	Expect(validateFieldValue(FieldTypeString, options, "GCP")).To(Succeed())
+	Expect(validateFieldValue(FieldTypeString, options, "Azure")).To(MatchError("must be one of: AWS, GCP"))
+
+	// unknown types of existing providers are not validated
+	Expect(validateFieldValue("text", FieldMetadata{}, "any")).To(Succeed())
+}
+
+func TestGetCapabilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	capabilities := (&DBaaSProviderSpec{}).GetCapabilities()
+	Expect(capabilities.SupportsProvisioning()).To(BeTrue())
+	Expect(capabilities.SupportsDeletion()).To(BeTrue())
+
+	// unset capabilities are supported, explicitly unsupported capabilities are not
+	capabilities = (&DBaaSProviderSpec{Capabilities: &DBaaSProviderCapabilities{Deletion: pointer.Bool(false)}}).GetCapabilities()
+	Expect(capabilities.SupportsProvisioning()).To(BeTrue())
+	Expect(capabilities.SupportsDeletion()).To(BeFalse())
+	Expect(capabilities.Provisioning).To(Equal(pointer.Bool(true)))
+
+	// the declared only capabilities are reported as declared
+	capabilities = (&DBaaSProviderSpec{Capabilities: &DBaaSProviderCapabilities{Scaling: true, Clone: true}}).GetCapabilities()
+	Expect(capabilities.Scaling).To(BeTrue())
+	Expect(capabilities.Clone).To(BeTrue())
+	Expect(capabilities.Backup).To(BeFalse())
+	Expect(capabilities.CredentialRotation).To(BeFalse())
+}
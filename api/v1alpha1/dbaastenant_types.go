@@ -33,16 +33,84 @@ type DBaaSTenantSpec struct {
 	// Each inventory can individually override this. Use "*" to allow all namespaces.
 	// If not set in either the tenant or inventory object, connections will only be allowed in the inventory namespace.
 	ConnectionNamespaces []string `json:"connectionNamespaces,omitempty"`
+	// Default selector for namespaces where DBaaSConnections/DBaaSInstances are allowed to reference a tenant's inventories,
+	// in addition to connectionNamespaces. Each inventory can individually override this.
+	ConnectionNamespaceSelector *metav1.LabelSelector `json:"connectionNamespaceSelector,omitempty"`
+	// Limits on the number of DBaaSInstances/DBaaSConnections referencing a tenant's inventories.
+	// If not set, no limits are enforced.
+	Quotas *DBaaSTenantQuotas `json:"quotas,omitempty"`
+	// Values DBaaSInstances are allowed to use when provisioning against a tenant's inventories.
+	// If not set, all values are allowed.
+	AllowedValues *DBaaSTenantAllowedValues `json:"allowedValues,omitempty"`
+	// Default time to live for DBaaSInstances provisioned against free trial providers in a tenant's inventories.
+	// Only applies to instances which set neither ttl nor expiresAt.
+	TrialInstanceTTL *metav1.Duration `json:"trialInstanceTTL,omitempty"`
+}
+
+// DBaaSTenantQuotas defines the limits enforced for a tenant's inventories
+type DBaaSTenantQuotas struct {
+	// Maximum number of DBaaSInstances referencing the tenant's inventories
+	// +kubebuilder:validation:Minimum=0
+	MaxInstances *int32 `json:"maxInstances,omitempty"`
+	// Maximum number of DBaaSConnections referencing the tenant's inventories in any single namespace
+	// +kubebuilder:validation:Minimum=0
+	MaxConnectionsPerNamespace *int32 `json:"maxConnectionsPerNamespace,omitempty"`
+}
+
+// DBaaSTenantAllowedValues defines the values allowed when provisioning instances against a tenant's inventories.
+// An empty list allows all values.
+type DBaaSTenantAllowedValues struct {
+	// Names of the DBaaSProviders whose inventories may be used for provisioning
+	Providers []string `json:"providers,omitempty"`
+	// Allowed values for a DBaaSInstance's cloudProvider
+	CloudProviders []string `json:"cloudProviders,omitempty"`
+	// Allowed values for a DBaaSInstance's cloudRegion
+	CloudRegions []string `json:"cloudRegions,omitempty"`
+	// Allowed values for a DBaaSInstance's otherInstanceParams, keyed by parameter name
+	InstanceParams map[string][]string `json:"instanceParams,omitempty"`
 }
 
 // DBaaSTenantStatus defines the observed state of DBaaSTenant
 type DBaaSTenantStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Users and groups resolved as service admins, able to create inventories and secrets in the inventory namespace
+	ServiceAdmins DBaaSTenantSubjects `json:"serviceAdmins,omitempty"`
+	// Users and groups resolved as developers, able to list or get inventories in the inventory namespace
+	Developers DBaaSTenantSubjects `json:"developers,omitempty"`
+	// Number of DBaaSInventories in the inventory namespace, by readiness
+	Inventories DBaaSTenantInventoryCounts `json:"inventories,omitempty"`
+	// Effective namespaces where DBaaSConnections/DBaaSInstances may reference the tenant's inventories: the inventory
+	// namespace, and the namespaces allowed by each inventory, whose settings override the tenant defaults, including
+	// the namespaces matching the selectors. "*" when all namespaces are allowed.
+	ConnectionNamespaces []string `json:"connectionNamespaces,omitempty"`
+	// Current usage of the tenant's quotas
+	Usage *DBaaSTenantUsage `json:"usage,omitempty"`
+}
+
+// DBaaSTenantSubjects lists the users and groups granted a tenant role
+type DBaaSTenantSubjects struct {
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// DBaaSTenantInventoryCounts reports the number of a tenant's inventories by readiness
+type DBaaSTenantInventoryCounts struct {
+	Total    int32 `json:"total"`
+	Ready    int32 `json:"ready"`
+	NotReady int32 `json:"notReady"`
+}
+
+// DBaaSTenantUsage reports the objects counted against a tenant's quotas
+type DBaaSTenantUsage struct {
+	// Number of DBaaSInstances referencing the tenant's inventories
+	Instances int32 `json:"instances"`
+	// Number of DBaaSConnections referencing the tenant's inventories, keyed by namespace
+	ConnectionsPerNamespace map[string]int32 `json:"connectionsPerNamespace,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:printcolumn:name="Inventory_NS",type=string,JSONPath=`.spec.inventoryNamespace`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
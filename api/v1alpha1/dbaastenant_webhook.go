@@ -20,7 +20,10 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -67,15 +70,55 @@ func (r *DBaaSTenant) ValidateDelete() error {
 }
 
 func (r *DBaaSTenant) validateTenant() error {
+	if err := validateConnectionNamespaceSelector(r.Spec.ConnectionNamespaceSelector); err != nil {
+		return err
+	}
+
 	tenantsList := &DBaaSTenantList{}
 	if err := tenantWebhookApiClient.List(context.TODO(), tenantsList, client.MatchingFields{inventoryNamespaceKey: r.Spec.InventoryNamespace}); err != nil {
 		return err
 	}
 
-	if len(tenantsList.Items) > 0 {
-		errMsg := fmt.Sprintf("the namespace %s is already managed by tenant %s, it cannot be managed by another tenant", r.Spec.InventoryNamespace, tenantsList.Items[0].Name)
+	for _, tenant := range tenantsList.Items {
+		// the tenant itself is listed on update
+		if tenant.Name == r.Name {
+			continue
+		}
+		errMsg := fmt.Sprintf("the namespace %s is already managed by tenant %s, it cannot be managed by another tenant", r.Spec.InventoryNamespace, tenant.Name)
 		return field.Invalid(field.NewPath("spec").Child("inventoryNamespace"), r.Spec.InventoryNamespace, errMsg)
 	}
 
 	return nil
 }
+
+// checks that a connection namespace selector can be converted to a label selector
+func validateConnectionNamespaceSelector(selector *metav1.LabelSelector) error {
+	if selector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return field.Invalid(field.NewPath("spec").Child("connectionNamespaceSelector"), selector, err.Error())
+	}
+	return nil
+}
+
+// returns the inventory referenced by a DBaaSInstance/DBaaSConnection and the tenant managing the inventory namespace.
+// nil values are returned if either one doesn't exist.
+func getInventoryTenant(c client.Client, inventoryRef NamespacedName, namespace string) (*DBaaSInventory, *DBaaSTenant, error) {
+	inventory := &DBaaSInventory{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: inventoryRef.Name, Namespace: InventoryRefNamespace(inventoryRef, namespace)}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	tenantsList := &DBaaSTenantList{}
+	if err := c.List(context.TODO(), tenantsList, client.MatchingFields{inventoryNamespaceKey: inventory.Namespace}); err != nil {
+		return nil, nil, err
+	}
+	if len(tenantsList.Items) == 0 {
+		return inventory, nil, nil
+	}
+	return inventory, &tenantsList.Items[0], nil
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"testing"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var tenantName = "test-tenant"
@@ -65,6 +69,13 @@ var _ = Describe("DBaaSTenant Webhook", func() {
 			}, timeout, interval).Should(BeTrue())
 		})
 
+		It("should allow updating the DBaaSTenant", func() {
+			tenant := &DBaaSTenant{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSTenant), tenant)).Should(Succeed())
+			tenant.Spec.ConnectionNamespaces = []string{"dev-namespace"}
+			Expect(k8sClient.Update(ctx, tenant)).Should(Succeed())
+		})
+
 		Context("after creating DBaaSTenant of the same inventory namespace", func() {
 			It("should not allow creating DBaaSTenant", func() {
 				testTenant := &DBaaSTenant{
@@ -83,3 +94,24 @@ var _ = Describe("DBaaSTenant Webhook", func() {
 		})
 	})
 })
+
+func TestValidateTenantUpdate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(AddToScheme(scheme)).Should(Succeed())
+	tenant := testDBaaSTenant.DeepCopy()
+	apiClient := tenantWebhookApiClient
+	defer func() { tenantWebhookApiClient = apiClient }()
+	tenantWebhookApiClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenant).Build()
+
+	// the tenant itself, listed on update, doesn't conflict with the update
+	tenant.Spec.ConnectionNamespaces = []string{"dev-namespace"}
+	Expect(tenant.ValidateUpdate(testDBaaSTenant)).To(Succeed())
+
+	otherTenant := testDBaaSTenant.DeepCopy()
+	otherTenant.Name = "test-tenant-1"
+	Expect(otherTenant.ValidateCreate()).To(MatchError("spec.inventoryNamespace: Invalid value: \"test-namespace\": " +
+		"the namespace test-namespace is already managed by tenant test-tenant, it cannot be managed by another tenant"))
+}
@@ -21,14 +21,15 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialField) DeepCopyInto(out *CredentialField) {
 	*out = *in
+	in.FieldMetadata.DeepCopyInto(&out.FieldMetadata)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialField.
@@ -41,6 +42,132 @@ func (in *CredentialField) DeepCopy() *CredentialField {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSAccessGrant) DeepCopyInto(out *DBaaSAccessGrant) {
+	*out = *in
+	out.RoleRef = in.RoleRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAccessGrant.
+func (in *DBaaSAccessGrant) DeepCopy() *DBaaSAccessGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAccessGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSAccessReport) DeepCopyInto(out *DBaaSAccessReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAccessReport.
+func (in *DBaaSAccessReport) DeepCopy() *DBaaSAccessReport {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAccessReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSAccessReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSAccessReportEntry) DeepCopyInto(out *DBaaSAccessReportEntry) {
+	*out = *in
+	out.Subject = in.Subject
+	if in.GrantedBy != nil {
+		in, out := &in.GrantedBy, &out.GrantedBy
+		*out = make([]DBaaSAccessGrant, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAccessReportEntry.
+func (in *DBaaSAccessReportEntry) DeepCopy() *DBaaSAccessReportEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAccessReportEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSAccessReportList) DeepCopyInto(out *DBaaSAccessReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSAccessReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAccessReportList.
+func (in *DBaaSAccessReportList) DeepCopy() *DBaaSAccessReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAccessReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSAccessReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSAccessReportStatus) DeepCopyInto(out *DBaaSAccessReportStatus) {
+	*out = *in
+	if in.GeneratedAt != nil {
+		in, out := &in.GeneratedAt, &out.GeneratedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]DBaaSAccessReportEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAccessReportStatus.
+func (in *DBaaSAccessReportStatus) DeepCopy() *DBaaSAccessReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAccessReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
 	*out = *in
@@ -121,19 +248,19 @@ func (in *DBaaSConnectionStatus) DeepCopyInto(out *DBaaSConnectionStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.CredentialsRef != nil {
 		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	if in.ConnectionInfoRef != nil {
 		in, out := &in.ConnectionInfoRef, &out.ConnectionInfoRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 }
@@ -235,7 +362,7 @@ func (in *DBaaSInstanceStatus) DeepCopyInto(out *DBaaSInstanceStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -343,7 +470,7 @@ func (in *DBaaSInventoryStatus) DeepCopyInto(out *DBaaSInventoryStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -367,6 +494,31 @@ func (in *DBaaSInventoryStatus) DeepCopy() *DBaaSInventoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSOperatorInstanceSpec) DeepCopyInto(out *DBaaSOperatorInstanceSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	in.DBaaSInstanceSpec.DeepCopyInto(&out.DBaaSInstanceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorInstanceSpec.
+func (in *DBaaSOperatorInstanceSpec) DeepCopy() *DBaaSOperatorInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSOperatorInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSOperatorInventorySpec) DeepCopyInto(out *DBaaSOperatorInventorySpec) {
 	*out = *in
@@ -376,6 +528,11 @@ func (in *DBaaSOperatorInventorySpec) DeepCopyInto(out *DBaaSOperatorInventorySp
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConnectionNamespaceSelector != nil {
+		in, out := &in.ConnectionNamespaceSelector, &out.ConnectionNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	in.DBaaSInventorySpec.DeepCopyInto(&out.DBaaSInventorySpec)
 }
 
@@ -395,7 +552,7 @@ func (in *DBaaSPlatform) DeepCopyInto(out *DBaaSPlatform) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatform.
@@ -416,6 +573,65 @@ func (in *DBaaSPlatform) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformConsolePlugin) DeepCopyInto(out *DBaaSPlatformConsolePlugin) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformConsolePlugin.
+func (in *DBaaSPlatformConsolePlugin) DeepCopy() *DBaaSPlatformConsolePlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformConsolePlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformImages) DeepCopyInto(out *DBaaSPlatformImages) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformImages.
+func (in *DBaaSPlatformImages) DeepCopy() *DBaaSPlatformImages {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSPlatformList) DeepCopyInto(out *DBaaSPlatformList) {
 	*out = *in
@@ -448,6 +664,107 @@ func (in *DBaaSPlatformList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformProvider) DeepCopyInto(out *DBaaSPlatformProvider) {
+	*out = *in
+	if in.ManifestsRef != nil {
+		in, out := &in.ManifestsRef, &out.ManifestsRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformProvider.
+func (in *DBaaSPlatformProvider) DeepCopy() *DBaaSPlatformProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformProviderStatus) DeepCopyInto(out *DBaaSPlatformProviderStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformProviderStatus.
+func (in *DBaaSPlatformProviderStatus) DeepCopy() *DBaaSPlatformProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformQuickStarts) DeepCopyInto(out *DBaaSPlatformQuickStarts) {
+	*out = *in
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformQuickStarts.
+func (in *DBaaSPlatformQuickStarts) DeepCopy() *DBaaSPlatformQuickStarts {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformQuickStarts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformSkipped) DeepCopyInto(out *DBaaSPlatformSkipped) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformSkipped.
+func (in *DBaaSPlatformSkipped) DeepCopy() *DBaaSPlatformSkipped {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformSkipped)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSPlatformSpec) DeepCopyInto(out *DBaaSPlatformSpec) {
 	*out = *in
@@ -456,6 +773,40 @@ func (in *DBaaSPlatformSpec) DeepCopyInto(out *DBaaSPlatformSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]DBaaSPlatformProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConsolePlugins != nil {
+		in, out := &in.ConsolePlugins, &out.ConsolePlugins
+		*out = make([]DBaaSPlatformConsolePlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(DBaaSPlatformTelemetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuickStarts != nil {
+		in, out := &in.QuickStarts, &out.QuickStarts
+		*out = new(DBaaSPlatformQuickStarts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(DBaaSPlatformImages)
+		**out = **in
+	}
+	if in.Uninstall != nil {
+		in, out := &in.Uninstall, &out.Uninstall
+		*out = new(DBaaSPlatformUninstall)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformSpec.
@@ -471,6 +822,28 @@ func (in *DBaaSPlatformSpec) DeepCopy() *DBaaSPlatformSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSPlatformStatus) DeepCopyInto(out *DBaaSPlatformStatus) {
 	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]DBaaSPlatformProviderStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]DBaaSPlatformSkipped, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Uninstall != nil {
+		in, out := &in.Uninstall, &out.Uninstall
+		*out = new(DBaaSPlatformUninstallStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformStatus.
@@ -483,13 +856,63 @@ func (in *DBaaSPlatformStatus) DeepCopy() *DBaaSPlatformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformTelemetry) DeepCopyInto(out *DBaaSPlatformTelemetry) {
+	*out = *in
+	if in.SegmentKeyRef != nil {
+		in, out := &in.SegmentKeyRef, &out.SegmentKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformTelemetry.
+func (in *DBaaSPlatformTelemetry) DeepCopy() *DBaaSPlatformTelemetry {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformTelemetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformUninstall) DeepCopyInto(out *DBaaSPlatformUninstall) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformUninstall.
+func (in *DBaaSPlatformUninstall) DeepCopy() *DBaaSPlatformUninstall {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformUninstall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformUninstallStatus) DeepCopyInto(out *DBaaSPlatformUninstallStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformUninstallStatus.
+func (in *DBaaSPlatformUninstallStatus) DeepCopy() *DBaaSPlatformUninstallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformUninstallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProvider) DeepCopyInto(out *DBaaSProvider) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProvider.
@@ -510,6 +933,58 @@ func (in *DBaaSProvider) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderCapabilities) DeepCopyInto(out *DBaaSProviderCapabilities) {
+	*out = *in
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CloudProviders != nil {
+		in, out := &in.CloudProviders, &out.CloudProviders
+		*out = make([]DBaaSProviderCloudProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderCapabilities.
+func (in *DBaaSProviderCapabilities) DeepCopy() *DBaaSProviderCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderCloudProvider) DeepCopyInto(out *DBaaSProviderCloudProvider) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderCloudProvider.
+func (in *DBaaSProviderCloudProvider) DeepCopy() *DBaaSProviderCloudProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderCloudProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProviderConnection) DeepCopyInto(out *DBaaSProviderConnection) {
 	*out = *in
@@ -603,12 +1078,21 @@ func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
 	if in.CredentialFields != nil {
 		in, out := &in.CredentialFields, &out.CredentialFields
 		*out = make([]CredentialField, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.InstanceParameterSpecs != nil {
 		in, out := &in.InstanceParameterSpecs, &out.InstanceParameterSpecs
 		*out = make([]InstanceParameterSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(DBaaSProviderCapabilities)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -625,6 +1109,18 @@ func (in *DBaaSProviderSpec) DeepCopy() *DBaaSProviderSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSProviderStatus) DeepCopyInto(out *DBaaSProviderStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(DBaaSProviderCapabilities)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderStatus.
@@ -643,7 +1139,7 @@ func (in *DBaaSTenant) DeepCopyInto(out *DBaaSTenant) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenant.
@@ -664,6 +1160,66 @@ func (in *DBaaSTenant) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSTenantAllowedValues) DeepCopyInto(out *DBaaSTenantAllowedValues) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudProviders != nil {
+		in, out := &in.CloudProviders, &out.CloudProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudRegions != nil {
+		in, out := &in.CloudRegions, &out.CloudRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceParams != nil {
+		in, out := &in.InstanceParams, &out.InstanceParams
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantAllowedValues.
+func (in *DBaaSTenantAllowedValues) DeepCopy() *DBaaSTenantAllowedValues {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSTenantAllowedValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSTenantInventoryCounts) DeepCopyInto(out *DBaaSTenantInventoryCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantInventoryCounts.
+func (in *DBaaSTenantInventoryCounts) DeepCopy() *DBaaSTenantInventoryCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSTenantInventoryCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSTenantList) DeepCopyInto(out *DBaaSTenantList) {
 	*out = *in
@@ -696,6 +1252,31 @@ func (in *DBaaSTenantList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSTenantQuotas) DeepCopyInto(out *DBaaSTenantQuotas) {
+	*out = *in
+	if in.MaxInstances != nil {
+		in, out := &in.MaxInstances, &out.MaxInstances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConnectionsPerNamespace != nil {
+		in, out := &in.MaxConnectionsPerNamespace, &out.MaxConnectionsPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantQuotas.
+func (in *DBaaSTenantQuotas) DeepCopy() *DBaaSTenantQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSTenantQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSTenantSpec) DeepCopyInto(out *DBaaSTenantSpec) {
 	*out = *in
@@ -704,6 +1285,26 @@ func (in *DBaaSTenantSpec) DeepCopyInto(out *DBaaSTenantSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConnectionNamespaceSelector != nil {
+		in, out := &in.ConnectionNamespaceSelector, &out.ConnectionNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(DBaaSTenantQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = new(DBaaSTenantAllowedValues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrialInstanceTTL != nil {
+		in, out := &in.TrialInstanceTTL, &out.TrialInstanceTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantSpec.
@@ -719,6 +1320,26 @@ func (in *DBaaSTenantSpec) DeepCopy() *DBaaSTenantSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBaaSTenantStatus) DeepCopyInto(out *DBaaSTenantStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ServiceAdmins.DeepCopyInto(&out.ServiceAdmins)
+	in.Developers.DeepCopyInto(&out.Developers)
+	out.Inventories = in.Inventories
+	if in.ConnectionNamespaces != nil {
+		in, out := &in.ConnectionNamespaces, &out.ConnectionNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(DBaaSTenantUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantStatus.
@@ -731,6 +1352,53 @@ func (in *DBaaSTenantStatus) DeepCopy() *DBaaSTenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSTenantSubjects) DeepCopyInto(out *DBaaSTenantSubjects) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantSubjects.
+func (in *DBaaSTenantSubjects) DeepCopy() *DBaaSTenantSubjects {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSTenantSubjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSTenantUsage) DeepCopyInto(out *DBaaSTenantUsage) {
+	*out = *in
+	if in.ConnectionsPerNamespace != nil {
+		in, out := &in.ConnectionsPerNamespace, &out.ConnectionsPerNamespace
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSTenantUsage.
+func (in *DBaaSTenantUsage) DeepCopy() *DBaaSTenantUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSTenantUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseProvider) DeepCopyInto(out *DatabaseProvider) {
 	*out = *in
@@ -747,6 +1415,76 @@ func (in *DatabaseProvider) DeepCopy() *DatabaseProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldCondition) DeepCopyInto(out *FieldCondition) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldCondition.
+func (in *FieldCondition) DeepCopy() *FieldCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldMetadata) DeepCopyInto(out *FieldMetadata) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]FieldOption, len(*in))
+		copy(*out, *in)
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = **in
+	}
+	if in.VisibleWhen != nil {
+		in, out := &in.VisibleWhen, &out.VisibleWhen
+		*out = new(FieldCondition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldMetadata.
+func (in *FieldMetadata) DeepCopy() *FieldMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldOption) DeepCopyInto(out *FieldOption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldOption.
+func (in *FieldOption) DeepCopy() *FieldOption {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -772,6 +1510,7 @@ func (in *Instance) DeepCopy() *Instance {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceParameterSpec) DeepCopyInto(out *InstanceParameterSpec) {
 	*out = *in
+	in.FieldMetadata.DeepCopyInto(&out.FieldMetadata)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceParameterSpec.
@@ -804,7 +1543,7 @@ func (in *PlatformConfig) DeepCopyInto(out *PlatformConfig) {
 	*out = *in
 	if in.Envs != nil {
 		in, out := &in.Envs, &out.Envs
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
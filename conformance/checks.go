@@ -0,0 +1,160 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// InstancePhases are the phase values of provider instances
+var InstancePhases = []string{
+	v1alpha1.InstancePhasePending,
+	v1alpha1.InstancePhaseCreating,
+	v1alpha1.InstancePhaseUpdating,
+	v1alpha1.InstancePhaseDeleting,
+	v1alpha1.InstancePhaseDeleted,
+	v1alpha1.InstancePhaseReady,
+	v1alpha1.InstancePhaseFailed,
+}
+
+// CheckInventory returns the contract violations of a provider inventory: its status must parse as a DBaaSInventoryStatus,
+// and the instances discovered once the SpecSynced condition is true must have unique IDs
+func CheckInventory(obj *unstructured.Unstructured) []string {
+	inventory := &v1alpha1.DBaaSProviderInventory{}
+	if err := parseProviderObject(obj, inventory); err != nil {
+		return []string{fmt.Sprintf("the object cannot be parsed as a provider inventory: %v", err)}
+	}
+	violations := checkCondition(inventory.Status.Conditions, v1alpha1.DBaaSInventoryProviderSyncType)
+	if !apimeta.IsStatusConditionTrue(inventory.Status.Conditions, v1alpha1.DBaaSInventoryProviderSyncType) {
+		return violations
+	}
+	instanceIDs := map[string]bool{}
+	for i, instance := range inventory.Status.Instances {
+		if len(instance.InstanceID) == 0 {
+			violations = append(violations, fmt.Sprintf("status.instances[%d].instanceID is empty", i))
+		} else if instanceIDs[instance.InstanceID] {
+			violations = append(violations, fmt.Sprintf("status.instances[%d].instanceID %s is duplicated", i, instance.InstanceID))
+		}
+		instanceIDs[instance.InstanceID] = true
+	}
+	return violations
+}
+
+// CheckConnection returns the contract violations of a provider connection: its status must parse as a DBaaSConnectionStatus,
+// and once the ReadyForBinding condition is true, it must reference a credentials Secret and a connection information
+// ConfigMap existing in the connection namespace
+func CheckConnection(ctx context.Context, c client.Reader, obj *unstructured.Unstructured) ([]string, error) {
+	connection := &v1alpha1.DBaaSProviderConnection{}
+	if err := parseProviderObject(obj, connection); err != nil {
+		return []string{fmt.Sprintf("the object cannot be parsed as a provider connection: %v", err)}, nil
+	}
+	violations := checkCondition(connection.Status.Conditions, v1alpha1.DBaaSConnectionProviderSyncType)
+	if !apimeta.IsStatusConditionTrue(connection.Status.Conditions, v1alpha1.DBaaSConnectionProviderSyncType) {
+		return violations, nil
+	}
+
+	if connection.Status.CredentialsRef == nil {
+		violations = append(violations, "status.credentialsRef is not set")
+	} else if err := c.Get(ctx, types.NamespacedName{Name: connection.Status.CredentialsRef.Name, Namespace: obj.GetNamespace()}, &corev1.Secret{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return violations, err
+		}
+		violations = append(violations, fmt.Sprintf("status.credentialsRef references the Secret %s, which does not exist", connection.Status.CredentialsRef.Name))
+	}
+	if connection.Status.ConnectionInfoRef == nil {
+		violations = append(violations, "status.connectionInfoRef is not set")
+	} else if err := c.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: obj.GetNamespace()}, &corev1.ConfigMap{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return violations, err
+		}
+		violations = append(violations, fmt.Sprintf("status.connectionInfoRef references the ConfigMap %s, which does not exist", connection.Status.ConnectionInfoRef.Name))
+	}
+	return violations, nil
+}
+
+// CheckInstance returns the contract violations of a provider instance: its status must parse as a DBaaSInstanceStatus,
+// with a known phase, and once the ProvisionReady condition is true, it must be in the Ready phase with an instance ID
+func CheckInstance(obj *unstructured.Unstructured) []string {
+	instance := &v1alpha1.DBaaSProviderInstance{}
+	if err := parseProviderObject(obj, instance); err != nil {
+		return []string{fmt.Sprintf("the object cannot be parsed as a provider instance: %v", err)}
+	}
+	violations := checkCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceProviderSyncType)
+	if len(instance.Status.Phase) > 0 && !contains(InstancePhases, instance.Status.Phase) {
+		violations = append(violations, fmt.Sprintf("status.phase %s is not one of %v", instance.Status.Phase, InstancePhases))
+	}
+	if !apimeta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.DBaaSInstanceProviderSyncType) {
+		return violations
+	}
+	if instance.Status.Phase != v1alpha1.InstancePhaseReady {
+		violations = append(violations, fmt.Sprintf("status.phase is %s instead of %s, while the %s condition is true",
+			instance.Status.Phase, v1alpha1.InstancePhaseReady, v1alpha1.DBaaSInstanceProviderSyncType))
+	}
+	if len(instance.Status.InstanceID) == 0 {
+		violations = append(violations, fmt.Sprintf("status.instanceID is empty, while the %s condition is true", v1alpha1.DBaaSInstanceProviderSyncType))
+	}
+	return violations
+}
+
+// checks the condition the DBaaS operator merges into the status of its own resources, if set
+func checkCondition(conditions []metav1.Condition, condType string) []string {
+	cond := apimeta.FindStatusCondition(conditions, condType)
+	if cond == nil {
+		return nil
+	}
+	var violations []string
+	switch cond.Status {
+	case metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+	default:
+		violations = append(violations, fmt.Sprintf("the %s condition status %s is not True, False or Unknown", condType, cond.Status))
+	}
+	if len(cond.Reason) == 0 {
+		violations = append(violations, fmt.Sprintf("the %s condition has no reason", condType))
+	}
+	return violations
+}
+
+// parses a provider object the way the DBaaS operator does
+func parseProviderObject(obj *unstructured.Unstructured, object interface{}) error {
+	b, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, object)
+}
+
+// checks if a string is present in a slice
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
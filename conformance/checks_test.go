@@ -0,0 +1,136 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+func TestCheckInventory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	inventory := newProviderObject(map[string]interface{}{
+		"conditions": []interface{}{newCondition(v1alpha1.DBaaSInventoryProviderSyncType, "True", "SyncOK")},
+		"instances": []interface{}{
+			map[string]interface{}{"instanceID": "instance-1"},
+			map[string]interface{}{"instanceID": "instance-2"},
+		},
+	})
+	Expect(CheckInventory(inventory)).To(BeEmpty())
+
+	// instance IDs are checked once discovered
+	Expect(unstructured.SetNestedSlice(inventory.Object, []interface{}{
+		map[string]interface{}{"instanceID": "instance-1"},
+		map[string]interface{}{"instanceID": "instance-1"},
+		map[string]interface{}{"name": "instance-3"},
+	}, "status", "instances")).To(Succeed())
+	Expect(CheckInventory(inventory)).To(Equal([]string{
+		"status.instances[1].instanceID instance-1 is duplicated",
+		"status.instances[2].instanceID is empty",
+	}))
+
+	// the status must parse with the DBaaS operator schema
+	Expect(unstructured.SetNestedField(inventory.Object, "instance-1", "status", "instances")).To(Succeed())
+	Expect(CheckInventory(inventory)).To(HaveLen(1))
+	Expect(CheckInventory(inventory)[0]).To(HavePrefix("the object cannot be parsed as a provider inventory"))
+}
+
+func TestCheckInstance(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	instance := newProviderObject(map[string]interface{}{
+		"conditions": []interface{}{newCondition(v1alpha1.DBaaSInstanceProviderSyncType, "False", "")},
+		"phase":      "Provisioning",
+	})
+	Expect(CheckInstance(instance)).To(Equal([]string{
+		"the ProvisionReady condition has no reason",
+		"status.phase Provisioning is not one of [Pending Creating Updating Deleting Deleted Ready Failed]",
+	}))
+
+	Expect(unstructured.SetNestedSlice(instance.Object, []interface{}{
+		newCondition(v1alpha1.DBaaSInstanceProviderSyncType, "True", "SyncOK"),
+	}, "status", "conditions")).To(Succeed())
+	Expect(unstructured.SetNestedField(instance.Object, v1alpha1.InstancePhaseCreating, "status", "phase")).To(Succeed())
+	Expect(CheckInstance(instance)).To(Equal([]string{
+		"status.phase is Creating instead of Ready, while the ProvisionReady condition is true",
+		"status.instanceID is empty, while the ProvisionReady condition is true",
+	}))
+
+	Expect(unstructured.SetNestedField(instance.Object, v1alpha1.InstancePhaseReady, "status", "phase")).To(Succeed())
+	Expect(unstructured.SetNestedField(instance.Object, "instance-1", "status", "instanceID")).To(Succeed())
+	Expect(CheckInstance(instance)).To(BeEmpty())
+}
+
+func TestCheckConnection(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	connection := newProviderObject(map[string]interface{}{
+		"conditions": []interface{}{newCondition(v1alpha1.DBaaSConnectionProviderSyncType, "False", "NotFound")},
+	})
+	Expect(CheckConnection(context.Background(), c, connection)).To(BeEmpty())
+
+	// the refs are checked once ready for binding
+	Expect(unstructured.SetNestedField(connection.Object, map[string]interface{}{
+		"conditions":        []interface{}{newCondition(v1alpha1.DBaaSConnectionProviderSyncType, "True", "SyncOK")},
+		"credentialsRef":    map[string]interface{}{"name": "credentials"},
+		"connectionInfoRef": map[string]interface{}{"name": "configs"},
+	}, "status")).To(Succeed())
+	Expect(CheckConnection(context.Background(), c, connection)).To(Equal([]string{
+		"status.connectionInfoRef references the ConfigMap configs, which does not exist",
+	}))
+
+	unstructured.RemoveNestedField(connection.Object, "status", "credentialsRef")
+	Expect(c.Create(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "configs", Namespace: "default"}})).To(Succeed())
+	Expect(CheckConnection(context.Background(), c, connection)).To(Equal([]string{
+		"status.credentialsRef is not set",
+	}))
+}
+
+func newProviderObject(status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	obj.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("TestProviderKind"))
+	obj.SetName("test")
+	obj.SetNamespace("default")
+	return obj
+}
+
+func newCondition(condType string, status string, reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":               condType,
+		"status":             status,
+		"reason":             reason,
+		"message":            "",
+		"lastTransitionTime": "2022-01-01T00:00:00Z",
+	}
+}
@@ -0,0 +1,487 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance checks that a provider operator honors the contract the DBaaS operator relies on, by running
+// scripted scenarios against the provider inventory, connection and instance resources of a candidate provider.
+//
+// The scenarios run against a cluster, or envtest, where the provider operator is running:
+//
+//	report, err := conformance.Run(ctx, conformance.Config{
+//		Client:      k8sClient,
+//		Provider:    provider,
+//		Namespace:   "default",
+//		Credentials: map[string][]byte{"apiKey": []byte("...")},
+//	})
+//	if err != nil || len(report.Violations()) > 0 {
+//		t.Fatal(err, report)
+//	}
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// The conformance scenarios, in the order they run
+const (
+	ScenarioInventoryDiscovery          = "inventory-discovery"
+	ScenarioInventoryInvalidCredentials = "inventory-invalid-credentials"
+	ScenarioInstanceProvisioning        = "instance-provisioning"
+	ScenarioConnection                  = "connection"
+	ScenarioConnectionUnknownInstance   = "connection-unknown-instance"
+)
+
+const (
+	// DefaultTimeout is how long a scenario step waits for the provider by default
+	DefaultTimeout = 2 * time.Minute
+	// DefaultPollInterval is how often a scenario step checks the provider resources by default
+	DefaultPollInterval = time.Second
+
+	// the prefix of the names of the objects created by the scenarios
+	namePrefix = "conformance-"
+	// the instance ID of the connection to an unknown instance
+	unknownInstanceID = "conformance-unknown-instance"
+)
+
+// Config describes the candidate provider and the cluster where its operator runs
+type Config struct {
+	// Client to the cluster, with the core and dbaas v1alpha1 types in its scheme
+	Client client.Client
+
+	// Provider is the DBaaSProvider registering the candidate provider
+	Provider *v1alpha1.DBaaSProvider
+
+	// Namespace is where the scenarios create their objects, which are deleted when the scenarios complete
+	Namespace string
+
+	// Credentials are the data of a credentials Secret valid for the provider, with the provider credential fields as keys
+	Credentials map[string][]byte
+
+	// Instance is the spec of the instance to provision, without its inventory reference. Instance provisioning is
+	// skipped if not set, or if the provider doesn't support provisioning.
+	Instance *v1alpha1.DBaaSInstanceSpec
+
+	// Timeout is how long a scenario step waits for the provider, DefaultTimeout if not set
+	Timeout time.Duration
+
+	// PollInterval is how often a scenario step checks the provider resources, DefaultPollInterval if not set
+	PollInterval time.Duration
+}
+
+// Violation is a contract violation found while running a scenario
+type Violation struct {
+	// The scenario which found the violation
+	Scenario string
+	// The kind of the provider resource violating the contract
+	Kind string
+	// The name of the provider resource violating the contract
+	Name string
+	// The violated rule of the contract
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s: %s", v.Kind, v.Name, v.Message)
+}
+
+// ScenarioResult is the outcome of a scenario
+type ScenarioResult struct {
+	Name string
+	// Why the scenario was skipped, empty if it ran
+	Skipped string
+	// The contract violations found by the scenario
+	Violations []Violation
+}
+
+// Report lists the outcome of the conformance scenarios
+type Report struct {
+	Results []*ScenarioResult
+}
+
+// Violations returns the contract violations found by all scenarios
+func (r *Report) Violations() []Violation {
+	var violations []Violation
+	for _, result := range r.Results {
+		violations = append(violations, result.Violations...)
+	}
+	return violations
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		switch {
+		case len(result.Skipped) > 0:
+			fmt.Fprintf(&b, "SKIP %s: %s\n", result.Name, result.Skipped)
+		case len(result.Violations) > 0:
+			fmt.Fprintf(&b, "FAIL %s\n", result.Name)
+			for _, violation := range result.Violations {
+				fmt.Fprintf(&b, "  %s\n", violation)
+			}
+		default:
+			fmt.Fprintf(&b, "PASS %s\n", result.Name)
+		}
+	}
+	return b.String()
+}
+
+// Run runs the conformance scenarios against the candidate provider, and reports the contract violations found.
+// An error is returned if the scenarios could not run, with the report of the scenarios which ran.
+func Run(ctx context.Context, config Config) (*Report, error) {
+	r := &runner{Config: config, report: &Report{}}
+	if r.Timeout == 0 {
+		r.Timeout = DefaultTimeout
+	}
+	if r.PollInterval == 0 {
+		r.PollInterval = DefaultPollInterval
+	}
+	defer r.cleanup()
+
+	inventory, err := r.runInventoryDiscovery(ctx)
+	if err != nil {
+		return r.report, err
+	}
+	if err := r.runInventoryInvalidCredentials(ctx); err != nil {
+		return r.report, err
+	}
+	instanceID, err := r.runInstanceProvisioning(ctx, inventory)
+	if err != nil {
+		return r.report, err
+	}
+	if err := r.runConnection(ctx, inventory, instanceID); err != nil {
+		return r.report, err
+	}
+	if err := r.runConnectionUnknownInstance(ctx, inventory); err != nil {
+		return r.report, err
+	}
+	return r.report, nil
+}
+
+type runner struct {
+	Config
+	report  *Report
+	created []client.Object
+}
+
+// checks an inventory discovers the instances of the provider account
+func (r *runner) runInventoryDiscovery(ctx context.Context) (*unstructured.Unstructured, error) {
+	result := r.startScenario(ScenarioInventoryDiscovery)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: namePrefix,
+			Namespace:    r.Namespace,
+		},
+		Data: r.Credentials,
+	}
+	if err := r.create(ctx, secret); err != nil {
+		return nil, err
+	}
+	inventory, err := r.createProviderObject(ctx, r.Provider.Spec.InventoryKind, &v1alpha1.DBaaSInventorySpec{
+		CredentialsRef: &v1alpha1.NamespacedName{Name: secret.Name, Namespace: r.Namespace},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	synced, err := r.poll(ctx, result, inventory, checkInventory, isConditionTrue(v1alpha1.DBaaSInventoryProviderSyncType))
+	if err != nil || synced {
+		return inventory, err
+	}
+	r.addViolations(result, inventory, fmt.Sprintf("the %s condition is not true within %s, with valid credentials",
+		v1alpha1.DBaaSInventoryProviderSyncType, r.Timeout))
+	return nil, nil
+}
+
+// checks an inventory referencing missing credentials reports the error
+func (r *runner) runInventoryInvalidCredentials(ctx context.Context) error {
+	result := r.startScenario(ScenarioInventoryInvalidCredentials)
+	inventory, err := r.createProviderObject(ctx, r.Provider.Spec.InventoryKind, &v1alpha1.DBaaSInventorySpec{
+		CredentialsRef: &v1alpha1.NamespacedName{Name: namePrefix + "missing-credentials", Namespace: r.Namespace},
+	})
+	if err != nil {
+		return err
+	}
+
+	reported, err := r.poll(ctx, result, inventory, checkInventory, isConditionSet(v1alpha1.DBaaSInventoryProviderSyncType))
+	if err != nil {
+		return err
+	}
+	if !reported {
+		r.addViolations(result, inventory, fmt.Sprintf("the %s condition is not set within %s, with missing credentials",
+			v1alpha1.DBaaSInventoryProviderSyncType, r.Timeout))
+	} else if isConditionTrue(v1alpha1.DBaaSInventoryProviderSyncType)(inventory) {
+		r.addViolations(result, inventory, fmt.Sprintf("the %s condition is true, with missing credentials",
+			v1alpha1.DBaaSInventoryProviderSyncType))
+	}
+	return nil
+}
+
+// checks an instance goes through the provisioning phases until ready, and is then discovered by the inventory;
+// returns the ID of the provisioned instance
+func (r *runner) runInstanceProvisioning(ctx context.Context, inventory *unstructured.Unstructured) (string, error) {
+	result := r.startScenario(ScenarioInstanceProvisioning)
+	switch {
+	case r.Instance == nil:
+		result.Skipped = "no instance to provision is configured"
+		return "", nil
+	case !r.Provider.Spec.GetCapabilities().SupportsProvisioning():
+		result.Skipped = "the provider does not support provisioning"
+		return "", nil
+	case inventory == nil:
+		result.Skipped = "the inventory discovery failed"
+		return "", nil
+	}
+
+	spec := r.Instance.DeepCopy()
+	spec.InventoryRef = v1alpha1.NamespacedName{Name: inventory.GetName(), Namespace: inventory.GetNamespace()}
+	instance, err := r.createProviderObject(ctx, r.Provider.Spec.InstanceKind, spec)
+	if err != nil {
+		return "", err
+	}
+	ready, err := r.poll(ctx, result, instance, checkInstance, isConditionTrue(v1alpha1.DBaaSInstanceProviderSyncType))
+	if err != nil {
+		return "", err
+	}
+	if !ready {
+		r.addViolations(result, instance, fmt.Sprintf("the %s condition is not true within %s",
+			v1alpha1.DBaaSInstanceProviderSyncType, r.Timeout))
+		return "", nil
+	}
+
+	instanceID, _, _ := unstructured.NestedString(instance.Object, "status", "instanceID")
+	discovered, err := r.poll(ctx, result, inventory, checkInventory, func(obj *unstructured.Unstructured) bool {
+		return contains(getInstanceIDs(obj), instanceID)
+	})
+	if err != nil {
+		return "", err
+	}
+	if !discovered {
+		r.addViolations(result, inventory, fmt.Sprintf("the provisioned instance %s is not discovered within %s", instanceID, r.Timeout))
+	}
+	return instanceID, nil
+}
+
+// checks a connection to a discovered instance provides its credentials and connection information
+func (r *runner) runConnection(ctx context.Context, inventory *unstructured.Unstructured, instanceID string) error {
+	result := r.startScenario(ScenarioConnection)
+	if inventory == nil {
+		result.Skipped = "the inventory discovery failed"
+		return nil
+	}
+	if instanceIDs := getInstanceIDs(inventory); len(instanceIDs) > 0 && len(instanceID) == 0 {
+		instanceID = instanceIDs[0]
+	}
+	if len(instanceID) == 0 {
+		result.Skipped = "no instance was discovered or provisioned"
+		return nil
+	}
+
+	connection, err := r.createProviderObject(ctx, r.Provider.Spec.ConnectionKind, &v1alpha1.DBaaSConnectionSpec{
+		InventoryRef: v1alpha1.NamespacedName{Name: inventory.GetName(), Namespace: inventory.GetNamespace()},
+		InstanceID:   instanceID,
+	})
+	if err != nil {
+		return err
+	}
+	ready, err := r.poll(ctx, result, connection, r.checkConnection, isConditionTrue(v1alpha1.DBaaSConnectionProviderSyncType))
+	if err != nil || ready {
+		return err
+	}
+	r.addViolations(result, connection, fmt.Sprintf("the %s condition is not true within %s, for the discovered instance %s",
+		v1alpha1.DBaaSConnectionProviderSyncType, r.Timeout, instanceID))
+	return nil
+}
+
+// checks a connection to an instance the inventory doesn't know reports the error
+func (r *runner) runConnectionUnknownInstance(ctx context.Context, inventory *unstructured.Unstructured) error {
+	result := r.startScenario(ScenarioConnectionUnknownInstance)
+	if inventory == nil {
+		result.Skipped = "the inventory discovery failed"
+		return nil
+	}
+
+	connection, err := r.createProviderObject(ctx, r.Provider.Spec.ConnectionKind, &v1alpha1.DBaaSConnectionSpec{
+		InventoryRef: v1alpha1.NamespacedName{Name: inventory.GetName(), Namespace: inventory.GetNamespace()},
+		InstanceID:   unknownInstanceID,
+	})
+	if err != nil {
+		return err
+	}
+	reported, err := r.poll(ctx, result, connection, r.checkConnection, isConditionSet(v1alpha1.DBaaSConnectionProviderSyncType))
+	if err != nil {
+		return err
+	}
+	if !reported {
+		r.addViolations(result, connection, fmt.Sprintf("the %s condition is not set within %s, for an unknown instance",
+			v1alpha1.DBaaSConnectionProviderSyncType, r.Timeout))
+	} else if isConditionTrue(v1alpha1.DBaaSConnectionProviderSyncType)(connection) {
+		r.addViolations(result, connection, fmt.Sprintf("the %s condition is true, for an unknown instance",
+			v1alpha1.DBaaSConnectionProviderSyncType))
+	}
+	return nil
+}
+
+// adds the result of a scenario to the report
+func (r *runner) startScenario(name string) *ScenarioResult {
+	result := &ScenarioResult{Name: name}
+	r.report.Results = append(r.report.Results, result)
+	return result
+}
+
+// adds violations found on a provider object to the result of a scenario, once
+func (r *runner) addViolations(result *ScenarioResult, obj *unstructured.Unstructured, messages ...string) {
+	for _, message := range messages {
+		violation := Violation{
+			Scenario: result.Name,
+			Kind:     obj.GetKind(),
+			Name:     obj.GetName(),
+			Message:  message,
+		}
+		found := false
+		for _, v := range result.Violations {
+			if v == violation {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.Violations = append(result.Violations, violation)
+		}
+	}
+}
+
+// polls a provider object until done, checking the contract on each observed state; returns false on timeout
+func (r *runner) poll(ctx context.Context, result *ScenarioResult, obj *unstructured.Unstructured,
+	check func(context.Context, *unstructured.Unstructured) ([]string, error), done func(*unstructured.Unstructured) bool) (bool, error) {
+	pollCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	err := wait.PollImmediateUntil(r.PollInterval, func() (bool, error) {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return false, err
+		}
+		violations, err := check(ctx, obj)
+		if err != nil {
+			return false, err
+		}
+		r.addViolations(result, obj, violations...)
+		return done(obj), nil
+	}, pollCtx.Done())
+	if err == wait.ErrWaitTimeout {
+		return false, ctx.Err()
+	}
+	return err == nil, err
+}
+
+// creates a provider object of a kind, with a generated name, in the scenarios namespace
+func (r *runner) createProviderObject(ctx context.Context, kind string, spec interface{}) (*unstructured.Unstructured, error) {
+	specMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": specMap}}
+	obj.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(kind))
+	obj.SetGenerateName(namePrefix)
+	obj.SetNamespace(r.Namespace)
+	if err := r.create(ctx, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// creates an object, deleted when the scenarios complete
+func (r *runner) create(ctx context.Context, obj client.Object) error {
+	if err := r.Client.Create(ctx, obj); err != nil {
+		return err
+	}
+	r.created = append(r.created, obj)
+	return nil
+}
+
+// deletes the objects created by the scenarios, most recent first, on a best effort basis
+func (r *runner) cleanup() {
+	for i := len(r.created) - 1; i >= 0; i-- {
+		_ = r.Client.Delete(context.Background(), r.created[i], client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}
+}
+
+func (r *runner) checkConnection(ctx context.Context, obj *unstructured.Unstructured) ([]string, error) {
+	return CheckConnection(ctx, r.Client, obj)
+}
+
+func checkInventory(_ context.Context, obj *unstructured.Unstructured) ([]string, error) {
+	return CheckInventory(obj), nil
+}
+
+func checkInstance(_ context.Context, obj *unstructured.Unstructured) ([]string, error) {
+	return CheckInstance(obj), nil
+}
+
+// returns whether a condition of a provider object is true
+func isConditionTrue(condType string) func(*unstructured.Unstructured) bool {
+	return func(obj *unstructured.Unstructured) bool {
+		return apimeta.IsStatusConditionTrue(getConditions(obj), condType)
+	}
+}
+
+// returns whether a condition of a provider object is set, to true or false
+func isConditionSet(condType string) func(*unstructured.Unstructured) bool {
+	return func(obj *unstructured.Unstructured) bool {
+		cond := apimeta.FindStatusCondition(getConditions(obj), condType)
+		return cond != nil && cond.Status != metav1.ConditionUnknown
+	}
+}
+
+// returns the conditions of a provider object, nil if they cannot be parsed
+func getConditions(obj *unstructured.Unstructured) []metav1.Condition {
+	status := &struct {
+		Status struct {
+			Conditions []metav1.Condition `json:"conditions,omitempty"`
+		} `json:"status,omitempty"`
+	}{}
+	if err := parseProviderObject(obj, status); err != nil {
+		return nil
+	}
+	return status.Status.Conditions
+}
+
+// returns the sorted IDs of the instances discovered by an inventory, nil if they cannot be parsed
+func getInstanceIDs(obj *unstructured.Unstructured) []string {
+	inventory := &v1alpha1.DBaaSProviderInventory{}
+	if err := parseProviderObject(obj, inventory); err != nil {
+		return nil
+	}
+	var instanceIDs []string
+	for _, instance := range inventory.Status.Instances {
+		if len(instance.InstanceID) > 0 {
+			instanceIDs = append(instanceIDs, instance.InstanceID)
+		}
+	}
+	sort.Strings(instanceIDs)
+	return instanceIDs
+}
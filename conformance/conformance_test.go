@@ -0,0 +1,64 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	reference "github.com/RHEcosystemAppEng/dbaas-operator/reference/controllers"
+)
+
+var _ = Describe("Provider conformance", func() {
+	It("should find no contract violations in the reference provider", func() {
+		report, err := Run(ctx, Config{
+			Client:    k8sClient,
+			Provider:  reference.Provider(),
+			Namespace: testNamespace,
+			Credentials: map[string][]byte{
+				reference.CredentialAccountName:   []byte("conformance"),
+				reference.CredentialSeedInstances: []byte("orders"),
+			},
+			Instance: &v1alpha1.DBaaSInstanceSpec{
+				Name:          "payments",
+				CloudProvider: "Simulated",
+				CloudRegion:   "local",
+			},
+			Timeout: timeout,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Violations()).To(BeEmpty(), report.String())
+		for _, result := range report.Results {
+			Expect(result.Skipped).To(BeEmpty(), report.String())
+		}
+	})
+
+	It("should report the contract violations of a provider", func() {
+		// the reference provider doesn't discover instances without an account name
+		report, err := Run(ctx, Config{
+			Client:    k8sClient,
+			Provider:  reference.Provider(),
+			Namespace: testNamespace,
+			Timeout:   timeout / 10,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Violations()).To(HaveLen(1), report.String())
+		Expect(report.Violations()[0].Scenario).To(Equal(ScenarioInventoryDiscovery))
+		Expect(report.Violations()[0].Kind).To(Equal(reference.InventoryKind))
+	})
+})
@@ -0,0 +1,215 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	oauthzv1 "github.com/openshift/api/authorization/v1"
+	oauthzclientv1 "github.com/openshift/client-go/authorization/clientset/versioned/typed/authorization/v1"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OpenShiftAuthzBackendName selects the ResourceAccessReview based authz backend
+	OpenShiftAuthzBackendName = "openshift"
+	// RBACAuthzBackendName selects the authz backend evaluating RBAC objects from the cache
+	RBACAuthzBackendName = "rbac"
+)
+
+// AuthzBackend resolves the users and groups allowed to perform an action
+type AuthzBackend interface {
+	// ResourceAccessReview returns the users and groups who can perform the action.
+	// Service accounts are returned as users, in the "system:serviceaccount:<namespace>:<name>" format.
+	ResourceAccessReview(ctx context.Context, action oauthzv1.Action) (*oauthzv1.ResourceAccessReviewResponse, error)
+}
+
+// OpenShiftAuthzBackend resolves access using the OpenShift ResourceAccessReview API
+type OpenShiftAuthzBackend struct {
+	*oauthzclientv1.AuthorizationV1Client
+}
+
+var _ AuthzBackend = &OpenShiftAuthzBackend{}
+
+// ResourceAccessReview creates an OpenShift ResourceAccessReview for the action
+func (b *OpenShiftAuthzBackend) ResourceAccessReview(ctx context.Context, action oauthzv1.Action) (*oauthzv1.ResourceAccessReviewResponse, error) {
+	rar := &oauthzv1.ResourceAccessReview{Action: action}
+	rar.SetGroupVersionKind(oauthzv1.SchemeGroupVersion.WithKind("ResourceAccessReview"))
+	return b.AuthorizationV1Client.ResourceAccessReviews().Create(ctx, rar, metav1.CreateOptions{})
+}
+
+// RBACAuthzBackend resolves access by evaluating Roles, RoleBindings, ClusterRoles and ClusterRoleBindings,
+// for clusters without the OpenShift authorization API
+type RBACAuthzBackend struct {
+	client.Reader
+}
+
+var _ AuthzBackend = &RBACAuthzBackend{}
+
+// an RBAC binding, along with its subjects, service accounts defaulting to the binding namespace
+type authzBinding struct {
+	grant    v1alpha1.DBaaSAccessGrant
+	subjects []rbacv1.Subject
+}
+
+// ResourceAccessReview evaluates the cluster role bindings, and the role bindings in the action namespace, for the action
+func (b *RBACAuthzBackend) ResourceAccessReview(ctx context.Context, action oauthzv1.Action) (*oauthzv1.ResourceAccessReviewResponse, error) {
+	bindings, err := b.getAllowingBindings(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+
+	var users, groups []string
+	for _, binding := range bindings {
+		for _, subject := range binding.subjects {
+			switch subject.Kind {
+			case rbacv1.UserKind:
+				users = append(users, subject.Name)
+			case rbacv1.GroupKind:
+				groups = append(groups, subject.Name)
+			case rbacv1.ServiceAccountKind:
+				users = append(users, fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name))
+			}
+		}
+	}
+
+	return &oauthzv1.ResourceAccessReviewResponse{
+		Namespace:   action.Namespace,
+		UsersSlice:  uniqueStrSlice(users),
+		GroupsSlice: uniqueStrSlice(groups),
+	}, nil
+}
+
+// returns the cluster role bindings, and the role bindings in the action namespace, allowing the action
+func (b *RBACAuthzBackend) getAllowingBindings(ctx context.Context, action oauthzv1.Action) ([]authzBinding, error) {
+	var bindings []authzBinding
+	addBinding := func(kind, name, namespace string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) {
+		binding := authzBinding{
+			grant: v1alpha1.DBaaSAccessGrant{
+				Kind:      kind,
+				Name:      name,
+				Namespace: namespace,
+				RoleRef:   roleRef,
+			},
+		}
+		for _, subject := range subjects {
+			if subject.Kind == rbacv1.ServiceAccountKind && len(subject.Namespace) == 0 {
+				subject.Namespace = namespace
+			}
+			binding.subjects = append(binding.subjects, subject)
+		}
+		bindings = append(bindings, binding)
+	}
+
+	var clusterRoleBindingList rbacv1.ClusterRoleBindingList
+	if err := b.List(ctx, &clusterRoleBindingList); err != nil {
+		return nil, err
+	}
+	for _, binding := range clusterRoleBindingList.Items {
+		rules, err := b.getRoleRefRules(ctx, binding.RoleRef, "")
+		if err != nil {
+			return nil, err
+		}
+		if rulesAllow(rules, action) {
+			addBinding("ClusterRoleBinding", binding.Name, "", binding.RoleRef, binding.Subjects)
+		}
+	}
+
+	if len(action.Namespace) > 0 {
+		var roleBindingList rbacv1.RoleBindingList
+		if err := b.List(ctx, &roleBindingList, client.InNamespace(action.Namespace)); err != nil {
+			return nil, err
+		}
+		for _, binding := range roleBindingList.Items {
+			rules, err := b.getRoleRefRules(ctx, binding.RoleRef, binding.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			if rulesAllow(rules, action) {
+				addBinding("RoleBinding", binding.Name, binding.Namespace, binding.RoleRef, binding.Subjects)
+			}
+		}
+	}
+
+	return bindings, nil
+}
+
+// returns the rules of the role referenced by a binding, or no rules if the role doesn't exist
+func (b *RBACAuthzBackend) getRoleRefRules(ctx context.Context, roleRef rbacv1.RoleRef, namespace string) ([]rbacv1.PolicyRule, error) {
+	switch roleRef.Kind {
+	case "ClusterRole":
+		var clusterRole rbacv1.ClusterRole
+		if err := b.Get(ctx, types.NamespacedName{Name: roleRef.Name}, &clusterRole); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return clusterRole.Rules, nil
+	case "Role":
+		var role rbacv1.Role
+		if err := b.Get(ctx, types.NamespacedName{Name: roleRef.Name, Namespace: namespace}, &role); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return role.Rules, nil
+	}
+	return nil, nil
+}
+
+// checks if any of the rules allows the action
+func rulesAllow(rules []rbacv1.PolicyRule, action oauthzv1.Action) bool {
+	for _, rule := range rules {
+		if ruleAllows(rule, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks if a rule allows the action, supporting "*" wildcards
+func ruleAllows(rule rbacv1.PolicyRule, action oauthzv1.Action) bool {
+	if !matchesOrWildcard(rule.Verbs, action.Verb) ||
+		!matchesOrWildcard(rule.APIGroups, action.Group) ||
+		!matchesOrWildcard(rule.Resources, action.Resource) {
+		return false
+	}
+	// rules without resource names apply to all objects
+	if len(rule.ResourceNames) == 0 {
+		return true
+	}
+	return len(action.ResourceName) > 0 && contains(rule.ResourceNames, action.ResourceName)
+}
+
+// checks if a rule value list contains the value or the "*" wildcard
+func matchesOrWildcard(ruleValues []string, value string) bool {
+	for _, ruleValue := range ruleValues {
+		if ruleValue == rbacv1.ResourceAll || ruleValue == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	oauthzv1 "github.com/openshift/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RBAC authz backend", func() {
+	inventoryCreateAction := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "create",
+		Namespace: testNamespace,
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
+	}
+	inventoryGetAction := oauthzv1.Action{
+		Resource:     "dbaasinventories",
+		Verb:         "get",
+		ResourceName: "test-backend-inventory",
+		Namespace:    testNamespace,
+		Group:        v1alpha1.GroupVersion.Group,
+		Version:      v1alpha1.GroupVersion.Version,
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-backend-inventory-admin",
+			Namespace: testNamespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{v1alpha1.GroupVersion.Group},
+				Resources: []string{"dbaasinventories"},
+				Verbs:     []string{"create"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-backend-inventory-admins",
+			Namespace: testNamespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "test-backend-admin"},
+			{Kind: rbacv1.ServiceAccountKind, Name: "test-backend-sa"},
+		},
+	}
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-backend-inventory-viewer",
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{v1alpha1.GroupVersion.Group},
+				Resources:     []string{"dbaasinventories"},
+				ResourceNames: []string{"test-backend-inventory"},
+				Verbs:         []string{"get"},
+			},
+		},
+	}
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-backend-inventory-viewers",
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "test-backend-devs"},
+		},
+	}
+
+	BeforeEach(assertResourceCreation(role))
+	BeforeEach(assertResourceCreation(roleBinding))
+	BeforeEach(assertResourceCreation(clusterRole))
+	BeforeEach(assertResourceCreation(clusterRoleBinding))
+	AfterEach(assertResourceDeletion(clusterRoleBinding))
+	AfterEach(assertResourceDeletion(clusterRole))
+	AfterEach(assertResourceDeletion(roleBinding))
+	AfterEach(assertResourceDeletion(role))
+
+	It("should resolve subjects bound through role bindings", func() {
+		backend := &RBACAuthzBackend{Reader: dRec.Client}
+		Eventually(func() []string {
+			response, err := backend.ResourceAccessReview(ctx, inventoryCreateAction)
+			Expect(err).NotTo(HaveOccurred())
+			return response.UsersSlice
+		}, timeout).Should(ContainElements("test-backend-admin", "system:serviceaccount:"+testNamespace+":test-backend-sa"))
+
+		response, err := backend.ResourceAccessReview(ctx, inventoryCreateAction)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.GroupsSlice).NotTo(ContainElement("test-backend-devs"))
+	})
+
+	It("should resolve subjects bound through cluster role bindings with resource names", func() {
+		backend := &RBACAuthzBackend{Reader: dRec.Client}
+		Eventually(func() []string {
+			response, err := backend.ResourceAccessReview(ctx, inventoryGetAction)
+			Expect(err).NotTo(HaveOccurred())
+			return response.GroupsSlice
+		}, timeout).Should(ContainElement("test-backend-devs"))
+
+		otherInventoryGetAction := inventoryGetAction
+		otherInventoryGetAction.ResourceName = "other-inventory"
+		response, err := backend.ResourceAccessReview(ctx, otherInventoryGetAction)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.GroupsSlice).NotTo(ContainElement("test-backend-devs"))
+	})
+})
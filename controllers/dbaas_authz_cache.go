@@ -0,0 +1,242 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	oauthzv1 "github.com/openshift/api/authorization/v1"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the number of reviews cached by default
+const defaultAuthzCacheMaxEntries = 1000
+
+// CachedAuthzBackend caches the access reviews of another backend. The reviews are invalidated by the events of the
+// RBAC objects watched by the manager cache: the changes of a role or cluster role only invalidate the reviews of the
+// actions its rules allow, in its namespace or cluster wide, while the changes of a role binding invalidate the reviews
+// of its namespace, and those of a cluster role binding all the reviews. The least recently used reviews are evicted
+// once the cache is full. Concurrent identical reviews are coalesced into a single call to the backend.
+// The RBAC bindings granting the actions, listed by the access reports, are cached and invalidated the same way.
+type CachedAuthzBackend struct {
+	// Backend computes the reviews missing from the cache
+	Backend AuthzBackend
+	// GrantsReader reads the RBAC bindings and roles granting the actions missing from the cache
+	GrantsReader client.Reader
+	// MaxEntries is the number of cached reviews, 1000 if not set
+	MaxEntries int
+
+	mutex   sync.Mutex
+	entries map[authzCacheKey]*list.Element
+	// the cached reviews, most recently used first
+	lru *list.List
+}
+
+// the action attributes identifying a cached review, or the cached bindings granting the action
+type authzCacheKey struct {
+	namespace, verb, group, version, resource, resourceName string
+	grants                                                  bool
+}
+
+var _ AuthzBackend = &CachedAuthzBackend{}
+
+// a cached review or list of bindings, which may still be in flight until done is closed
+type authzCacheEntry struct {
+	key      authzCacheKey
+	action   oauthzv1.Action
+	response *oauthzv1.ResourceAccessReviewResponse
+	bindings []authzBinding
+	err      error
+	done     chan struct{}
+}
+
+// SetupWithManager invalidates the cached reviews on the events of the RBAC objects. The cluster roles, cluster role
+// bindings and roles share the informers of the controllers watching them, and the role bindings their metadata
+// informer.
+func (b *CachedAuthzBackend) SetupWithManager(mgr ctrl.Manager) error {
+	roleBindingMetadata := &metav1.PartialObjectMetadata{}
+	roleBindingMetadata.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("RoleBinding"))
+	for _, obj := range []client.Object{&rbacv1.ClusterRole{}, &rbacv1.ClusterRoleBinding{}, &rbacv1.Role{}, roleBindingMetadata} {
+		informer, err := mgr.GetCache().GetInformer(context.Background(), obj)
+		if err != nil {
+			return err
+		}
+		informer.AddEventHandler(b.rbacEventHandler())
+	}
+	return nil
+}
+
+// ResourceAccessReview returns the cached review for the action, if it hasn't been invalidated
+func (b *CachedAuthzBackend) ResourceAccessReview(ctx context.Context, action oauthzv1.Action) (*oauthzv1.ResourceAccessReviewResponse, error) {
+	entry, err := b.get(ctx, action, false, func(entry *authzCacheEntry) {
+		entry.response, entry.err = b.Backend.ResourceAccessReview(ctx, action)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.response.DeepCopy(), nil
+}
+
+// getAllowingBindings returns the cached cluster role bindings, and role bindings in the action namespace, allowing the
+// action, if they haven't been invalidated
+func (b *CachedAuthzBackend) getAllowingBindings(ctx context.Context, action oauthzv1.Action) ([]authzBinding, error) {
+	entry, err := b.get(ctx, action, true, func(entry *authzCacheEntry) {
+		entry.bindings, entry.err = (&RBACAuthzBackend{Reader: b.GrantsReader}).getAllowingBindings(ctx, action)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.bindings, nil
+}
+
+// returns the cache entry of the action, computing it if it is missing
+func (b *CachedAuthzBackend) get(ctx context.Context, action oauthzv1.Action, grants bool, computeFn func(entry *authzCacheEntry)) (*authzCacheEntry, error) {
+	key := authzCacheKey{
+		namespace:    action.Namespace,
+		verb:         action.Verb,
+		group:        action.Group,
+		version:      action.Version,
+		resource:     action.Resource,
+		resourceName: action.ResourceName,
+		grants:       grants,
+	}
+	b.mutex.Lock()
+	if b.entries == nil {
+		b.entries = map[authzCacheKey]*list.Element{}
+		b.lru = list.New()
+	}
+	var entry *authzCacheEntry
+	if element, ok := b.entries[key]; ok {
+		entry = element.Value.(*authzCacheEntry)
+		b.lru.MoveToFront(element)
+		b.mutex.Unlock()
+	} else {
+		entry = &authzCacheEntry{key: key, action: action, done: make(chan struct{})}
+		b.entries[key] = b.lru.PushFront(entry)
+		b.evict()
+		b.mutex.Unlock()
+		b.compute(entry, computeFn)
+	}
+
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return entry, nil
+}
+
+// evicts the least recently used reviews over the maximum number of entries, must be called with the mutex held
+func (b *CachedAuthzBackend) evict() {
+	maxEntries := b.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultAuthzCacheMaxEntries
+	}
+	for b.lru.Len() > maxEntries {
+		b.remove(b.lru.Back().Value.(*authzCacheEntry))
+	}
+}
+
+// removes a review from the cache, if it hasn't been replaced, must be called with the mutex held
+func (b *CachedAuthzBackend) remove(entry *authzCacheEntry) {
+	if element, ok := b.entries[entry.key]; ok && element.Value == entry {
+		b.lru.Remove(element)
+		delete(b.entries, entry.key)
+	}
+}
+
+// computes a cache entry, failed entries are removed from the cache to be retried
+func (b *CachedAuthzBackend) compute(entry *authzCacheEntry, computeFn func(entry *authzCacheEntry)) {
+	defer close(entry.done)
+	computeFn(entry)
+	if entry.err != nil {
+		b.mutex.Lock()
+		b.remove(entry)
+		b.mutex.Unlock()
+	}
+}
+
+// removes the cached reviews matching a filter, including those in flight, which are then recomputed on next use
+func (b *CachedAuthzBackend) invalidate(matches func(action oauthzv1.Action) bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.lru == nil {
+		return
+	}
+	for element := b.lru.Front(); element != nil; {
+		next := element.Next()
+		if entry := element.Value.(*authzCacheEntry); matches(entry.action) {
+			b.remove(entry)
+		}
+		element = next
+	}
+}
+
+// invalidates the cached reviews an RBAC object may affect
+func (b *CachedAuthzBackend) invalidateFor(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	switch o := obj.(type) {
+	case *rbacv1.ClusterRole:
+		b.invalidate(func(action oauthzv1.Action) bool {
+			return rulesAllow(o.Rules, action)
+		})
+	case *rbacv1.Role:
+		b.invalidate(func(action oauthzv1.Action) bool {
+			return action.Namespace == o.Namespace && rulesAllow(o.Rules, action)
+		})
+	case *metav1.PartialObjectMetadata:
+		// role bindings are only cached as metadata, their role is unknown
+		b.invalidate(func(action oauthzv1.Action) bool {
+			return action.Namespace == o.Namespace
+		})
+	default:
+		b.invalidate(func(action oauthzv1.Action) bool {
+			return true
+		})
+	}
+}
+
+// returns the handler invalidating the cached reviews on the events of the RBAC objects, the old and new objects of an
+// update are both evaluated, so that rules which stop allowing an action invalidate its reviews too. Resyncs, which
+// leave the resource version unchanged, are ignored.
+func (b *CachedAuthzBackend) rbacEventHandler() toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: b.invalidateFor,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldOK := oldObj.(metav1.Object)
+			newMeta, newOK := newObj.(metav1.Object)
+			if oldOK && newOK && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			b.invalidateFor(oldObj)
+			b.invalidateFor(newObj)
+		},
+		DeleteFunc: b.invalidateFor,
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	oauthzv1 "github.com/openshift/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// counts the reviews it computes
+type countingAuthzBackend struct {
+	calls int32
+	delay time.Duration
+	err   error
+}
+
+func (b *countingAuthzBackend) ResourceAccessReview(ctx context.Context, action oauthzv1.Action) (*oauthzv1.ResourceAccessReviewResponse, error) {
+	atomic.AddInt32(&b.calls, 1)
+	time.Sleep(b.delay)
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &oauthzv1.ResourceAccessReviewResponse{UsersSlice: []string{"user1"}}, nil
+}
+
+func TestCachedAuthzBackend(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	inventoryRule := rbacv1.PolicyRule{
+		APIGroups: []string{v1alpha1.GroupVersion.Group},
+		Resources: []string{"dbaasinventories"},
+		Verbs:     []string{"get", "list"},
+	}
+	otherRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list"}}
+	backend := &countingAuthzBackend{}
+	cachedBackend := &CachedAuthzBackend{Backend: backend}
+	handler := cachedBackend.rbacEventHandler()
+	action := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "list",
+		Namespace: "test",
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
+	}
+	ctx := context.Background()
+	expectReviews := func(calls int32) {
+		_, err := cachedBackend.ResourceAccessReview(ctx, action)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.calls).To(Equal(calls))
+	}
+	objectMeta := func(name, namespace, resourceVersion string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, ResourceVersion: resourceVersion}
+	}
+
+	// repeated reviews without RBAC changes are only computed once
+	for i := 0; i < 10; i++ {
+		response, err := cachedBackend.ResourceAccessReview(ctx, action)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.UsersSlice).To(Equal([]string{"user1"}))
+	}
+	Expect(backend.calls).To(Equal(int32(1)))
+
+	// other actions are cached separately
+	getAction := action
+	getAction.Verb = "get"
+	getAction.ResourceName = "inventory"
+	_, err := cachedBackend.ResourceAccessReview(ctx, getAction)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(backend.calls).To(Equal(int32(2)))
+
+	// the changes of the roles allowing the action, in the namespace or cluster wide, invalidate the cache
+	handler.OnUpdate(
+		&rbacv1.Role{ObjectMeta: objectMeta("dev", "test", "1"), Rules: []rbacv1.PolicyRule{otherRule}},
+		&rbacv1.Role{ObjectMeta: objectMeta("dev", "test", "2"), Rules: []rbacv1.PolicyRule{otherRule, inventoryRule}})
+	expectReviews(3)
+	handler.OnDelete(toolscache.DeletedFinalStateUnknown{Obj: &rbacv1.ClusterRole{ObjectMeta: objectMeta("admin", "", "1"), Rules: []rbacv1.PolicyRule{inventoryRule}}})
+	expectReviews(4)
+	expectReviews(4)
+
+	// the changes of roles not allowing the action, of roles in other namespaces, and resyncs don't invalidate the cache
+	handler.OnAdd(&rbacv1.ClusterRole{ObjectMeta: objectMeta("other", "", "1"), Rules: []rbacv1.PolicyRule{otherRule}})
+	handler.OnAdd(&rbacv1.Role{ObjectMeta: objectMeta("dev", "other", "1"), Rules: []rbacv1.PolicyRule{inventoryRule}})
+	role := &rbacv1.Role{ObjectMeta: objectMeta("dev", "test", "2"), Rules: []rbacv1.PolicyRule{inventoryRule}}
+	handler.OnUpdate(role, role)
+	expectReviews(4)
+
+	// role bindings, only cached as metadata, invalidate their namespace, and cluster role bindings the whole cache
+	handler.OnAdd(&metav1.PartialObjectMetadata{ObjectMeta: objectMeta("devs", "other", "1")})
+	expectReviews(4)
+	handler.OnAdd(&metav1.PartialObjectMetadata{ObjectMeta: objectMeta("devs", "test", "1")})
+	expectReviews(5)
+	handler.OnAdd(&rbacv1.ClusterRoleBinding{ObjectMeta: objectMeta("admins", "", "1")})
+	expectReviews(6)
+
+	// concurrent reviews are coalesced
+	handler.OnAdd(&metav1.PartialObjectMetadata{ObjectMeta: objectMeta("devs", "test", "2")})
+	backend.delay = 50 * time.Millisecond
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			_, err := cachedBackend.ResourceAccessReview(ctx, action)
+			Expect(err).NotTo(HaveOccurred())
+		}()
+	}
+	wg.Wait()
+	Expect(backend.calls).To(Equal(int32(7)))
+
+	// failed reviews are not cached
+	backend.delay = 0
+	backend.err = fmt.Errorf("review failed")
+	handler.OnAdd(&metav1.PartialObjectMetadata{ObjectMeta: objectMeta("devs", "test", "3")})
+	_, err = cachedBackend.ResourceAccessReview(ctx, action)
+	Expect(err).To(MatchError("review failed"))
+	_, err = cachedBackend.ResourceAccessReview(ctx, action)
+	Expect(err).To(MatchError("review failed"))
+	Expect(backend.calls).To(Equal(int32(9)))
+
+	// the least recently used reviews are evicted once the cache is full
+	backend.err = nil
+	cachedBackend = &CachedAuthzBackend{Backend: backend, MaxEntries: 2}
+	for _, name := range []string{"inventory1", "inventory2", "inventory1", "inventory3", "inventory1", "inventory2"} {
+		getAction.ResourceName = name
+		_, err = cachedBackend.ResourceAccessReview(ctx, getAction)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(backend.calls).To(Equal(int32(13)))
+	Expect(cachedBackend.lru.Len()).To(Equal(2))
+}
+
+func TestCachedAuthzBackendGrants(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "test"},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{v1alpha1.GroupVersion.Group},
+			Resources: []string{"dbaasinventories"},
+			Verbs:     []string{"list"},
+		}},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "devs", Namespace: "test"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "dev"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "user1"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(role, roleBinding).Build()
+	cachedBackend := &CachedAuthzBackend{Backend: &countingAuthzBackend{}, GrantsReader: c}
+	handler := cachedBackend.rbacEventHandler()
+	action := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "list",
+		Namespace: "test",
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
+	}
+	ctx := context.Background()
+	expectGrants := func(names ...string) {
+		bindings, err := cachedBackend.getAllowingBindings(ctx, action)
+		Expect(err).NotTo(HaveOccurred())
+		var grantNames []string
+		for _, binding := range bindings {
+			grantNames = append(grantNames, binding.grant.Name)
+		}
+		Expect(grantNames).To(Equal(names))
+	}
+
+	// the bindings are resolved once, and cached separately from the reviews of the same action
+	expectGrants("devs")
+	_, err := cachedBackend.ResourceAccessReview(ctx, action)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cachedBackend.lru.Len()).To(Equal(2))
+	Expect(c.Delete(ctx, roleBinding)).Should(Succeed())
+	expectGrants("devs")
+
+	// the bindings are only resolved again once the RBAC objects change
+	handler.OnDelete(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "devs", Namespace: "test", ResourceVersion: "1"}})
+	expectGrants()
+}
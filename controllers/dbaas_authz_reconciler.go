@@ -23,11 +23,11 @@ import (
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	oauthzv1 "github.com/openshift/api/authorization/v1"
-	oauthzclientv1 "github.com/openshift/client-go/authorization/clientset/versioned/typed/authorization/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,81 +36,77 @@ import (
 
 type DBaaSAuthzReconciler struct {
 	*DBaaSReconciler
-	*oauthzclientv1.AuthorizationV1Client
+	AuthzBackend AuthzBackend
+	// reads the RBAC bindings listed by the access reports, when the authz backend is not RBAC based
+	apiReader client.Reader
 }
 
 // ResourceAccessReview for Service Admin Authz
 // return users/groups who can create both inventory and secret objects in the tenant namespace
-func (r *DBaaSAuthzReconciler) getServiceAdminAuthz(ctx context.Context, namespace string) *oauthzv1.ResourceAccessReviewResponse {
+func (r *DBaaSAuthzReconciler) getServiceAdminAuthz(ctx context.Context, namespace string) (*oauthzv1.ResourceAccessReviewResponse, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	// admin access review
-	rar := &oauthzv1.ResourceAccessReview{
-		Action: oauthzv1.Action{
-			Resource:  "dbaasinventories",
-			Verb:      "create",
-			Namespace: namespace,
-			Group:     v1alpha1.GroupVersion.Group,
-			Version:   v1alpha1.GroupVersion.Version,
-		},
+	action := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "create",
+		Namespace: namespace,
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
 	}
-	rar.SetGroupVersionKind(oauthzv1.SchemeGroupVersion.WithKind("ResourceAccessReview"))
-	inventoryResponse, err := r.AuthorizationV1Client.ResourceAccessReviews().Create(ctx, rar, metav1.CreateOptions{})
+	inventoryResponse, err := r.AuthzBackend.ResourceAccessReview(ctx, action)
 	if err != nil {
-		logger.Error(err, "Error creating ResourceAccessReview", inventoryResponse.Namespace, inventoryResponse.EvaluationError)
-		return &oauthzv1.ResourceAccessReviewResponse{}
+		logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+		return nil, err
 	}
 
 	// secret access review
-	rar.Action.Resource = "secrets"
-	rar.Action.Group = corev1.SchemeGroupVersion.Group
-	rar.Action.Version = corev1.SchemeGroupVersion.Version
-	secretResponse, err := r.AuthorizationV1Client.ResourceAccessReviews().Create(ctx, rar, metav1.CreateOptions{})
+	action.Resource = "secrets"
+	action.Group = corev1.SchemeGroupVersion.Group
+	action.Version = corev1.SchemeGroupVersion.Version
+	secretResponse, err := r.AuthzBackend.ResourceAccessReview(ctx, action)
 	if err != nil {
-		logger.Error(err, "Error creating ResourceAccessReview", secretResponse.Namespace, secretResponse.EvaluationError)
-		return &oauthzv1.ResourceAccessReviewResponse{}
+		logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+		return nil, err
 	}
 
 	return &oauthzv1.ResourceAccessReviewResponse{
 		// remove results of tenant access review, as they don't need the addtl the perms
 		UsersSlice:  matchSlices(inventoryResponse.UsersSlice, secretResponse.UsersSlice),
 		GroupsSlice: matchSlices(inventoryResponse.GroupsSlice, secretResponse.GroupsSlice),
-	}
+	}, nil
 }
 
 // ResourceAccessReview for Developer Authz
 // return users/groups who can list or get individual inventories in the tenant namespace
-func (r *DBaaSAuthzReconciler) getDeveloperAuthz(ctx context.Context, namespace string, inventoryList v1alpha1.DBaaSInventoryList) *oauthzv1.ResourceAccessReviewResponse {
+func (r *DBaaSAuthzReconciler) getDeveloperAuthz(ctx context.Context, namespace string, inventoryList v1alpha1.DBaaSInventoryList) (*oauthzv1.ResourceAccessReviewResponse, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// inventory access review
-	rar := &oauthzv1.ResourceAccessReview{
-		Action: oauthzv1.Action{
-			Resource:  "dbaasinventories",
-			Verb:      "list",
-			Namespace: namespace,
-			Group:     v1alpha1.GroupVersion.Group,
-			Version:   v1alpha1.GroupVersion.Version,
-		},
+	action := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "list",
+		Namespace: namespace,
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
 	}
-	rar.SetGroupVersionKind(oauthzv1.SchemeGroupVersion.WithKind("ResourceAccessReview"))
-	inventoryResponse, err := r.AuthorizationV1Client.ResourceAccessReviews().Create(ctx, rar, metav1.CreateOptions{})
+	inventoryResponse, err := r.AuthzBackend.ResourceAccessReview(ctx, action)
 	if err != nil {
-		logger.Error(err, "Error creating ResourceAccessReview", inventoryResponse.Namespace, inventoryResponse.EvaluationError)
-		return &oauthzv1.ResourceAccessReviewResponse{}
+		logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+		return nil, err
 	}
 	users := inventoryResponse.UsersSlice
 	groups := inventoryResponse.GroupsSlice
 
 	// determine if any individual inventory access exists separate from list
-	//   ... will add additional api load, a new call for every inventory object in valid tenant namespaces
+	//   ... adds a review for every inventory object in valid tenant namespaces, which are cached by the backend until RBAC changes
 	//   ... but this keeps inventory-specific access functionality. a dev user could have access to a single inventory
 	for _, inv := range inventoryList.Items {
-		rar.Action.Verb = "get"
-		rar.Action.ResourceName = inv.Name
-		invResponse, err := r.AuthorizationV1Client.ResourceAccessReviews().Create(ctx, rar, metav1.CreateOptions{})
+		action.Verb = "get"
+		action.ResourceName = inv.Name
+		invResponse, err := r.AuthzBackend.ResourceAccessReview(ctx, action)
 		if err != nil {
-			logger.Error(err, "Error creating ResourceAccessReview", invResponse.Namespace, invResponse.EvaluationError)
-			return &oauthzv1.ResourceAccessReviewResponse{}
+			logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+			return nil, err
 		}
 		users = uniqueStrSlice(append(users, invResponse.UsersSlice...))
 		groups = uniqueStrSlice(append(groups, invResponse.GroupsSlice...))
@@ -119,30 +115,61 @@ func (r *DBaaSAuthzReconciler) getDeveloperAuthz(ctx context.Context, namespace
 	return &oauthzv1.ResourceAccessReviewResponse{
 		UsersSlice:  users,
 		GroupsSlice: groups,
-	}
+	}, nil
 }
 
 // ResourceAccessReview for Service Admin Authz
 // return users/groups who can list tenant objects at the cluster level
-func (r *DBaaSAuthzReconciler) getTenantListAuthz(ctx context.Context) *oauthzv1.ResourceAccessReviewResponse {
+func (r *DBaaSAuthzReconciler) getTenantListAuthz(ctx context.Context) (*oauthzv1.ResourceAccessReviewResponse, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	// tenant access review
-	rar := &oauthzv1.ResourceAccessReview{
-		Action: oauthzv1.Action{
-			Resource: "dbaastenants",
-			Verb:     "list",
-			Group:    v1alpha1.GroupVersion.Group,
-			Version:  v1alpha1.GroupVersion.Version,
-		},
+	action := oauthzv1.Action{
+		Resource: "dbaastenants",
+		Verb:     "list",
+		Group:    v1alpha1.GroupVersion.Group,
+		Version:  v1alpha1.GroupVersion.Version,
 	}
-	rar.SetGroupVersionKind(oauthzv1.SchemeGroupVersion.WithKind("ResourceAccessReview"))
-	tenantResponse, err := r.AuthorizationV1Client.ResourceAccessReviews().Create(ctx, rar, metav1.CreateOptions{})
+	tenantResponse, err := r.AuthzBackend.ResourceAccessReview(ctx, action)
 	if err != nil {
-		logger.Error(err, "Error creating ResourceAccessReview", tenantResponse.Namespace, tenantResponse.EvaluationError)
-		return &oauthzv1.ResourceAccessReviewResponse{}
+		logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+		return nil, err
 	}
 
-	return tenantResponse
+	return tenantResponse, nil
+}
+
+// Resolve the service admin, developer and tenant list authz for a tenant namespace.
+// Errors are returned instead of empty responses, so that RBAC isn't rewritten without any subjects.
+func (r *DBaaSAuthzReconciler) getTenantAuthz(ctx context.Context, namespace string, inventoryList v1alpha1.DBaaSInventoryList) (
+	serviceAdminAuthz, developerAuthz, tenantListAuthz *oauthzv1.ResourceAccessReviewResponse, err error) {
+	if serviceAdminAuthz, err = r.getServiceAdminAuthz(ctx, namespace); err != nil {
+		return nil, nil, nil, err
+	}
+	if developerAuthz, err = r.getDeveloperAuthz(ctx, namespace, inventoryList); err != nil {
+		return nil, nil, nil, err
+	}
+	if tenantListAuthz, err = r.getTenantListAuthz(ctx); err != nil {
+		return nil, nil, nil, err
+	}
+	return serviceAdminAuthz, developerAuthz, tenantListAuthz, nil
+}
+
+// Reconcile the RBAC objects and status of a tenant. When the access reviews failed, the existing RBAC objects
+// are left untouched, keeping the last known-good subjects until a retry succeeds.
+func (r *DBaaSAuthzReconciler) reconcileTenant(ctx context.Context, tenant v1alpha1.DBaaSTenant, inventoryList v1alpha1.DBaaSInventoryList,
+	serviceAdminAuthz, developerAuthz, tenantListAuthz *oauthzv1.ResourceAccessReviewResponse, reviewErr error) error {
+	var rbacErr error
+	if reviewErr == nil {
+		rbacErr = r.reconcileTenantRbacObjs(ctx, tenant, serviceAdminAuthz, developerAuthz, tenantListAuthz)
+	}
+	if err := r.updateTenantStatus(ctx, tenant, inventoryList, serviceAdminAuthz, developerAuthz, reviewErr, rbacErr); err != nil {
+		return err
+	}
+	// returning the error requeues the tenant with exponential backoff
+	if reviewErr != nil {
+		return reviewErr
+	}
+	return rbacErr
 }
 
 // Reconcile tenant to ensure proper RBAC is created. inventoryList should only contain inventory objects for the corresponding tenant namespace.
@@ -176,6 +203,287 @@ func (r *DBaaSAuthzReconciler) reconcileTenantRbacObjs(ctx context.Context, tena
 	return nil
 }
 
+// the connection namespaces of the inventories of a tenant namespace, resolved once for all its inventories: the
+// namespaces are only listed when selectors or the wildcard require it, and each connection namespace is only
+// reviewed once
+type connectionNSAuthz struct {
+	tenants    []v1alpha1.DBaaSTenant
+	namespaces *corev1.NamespaceList
+	reviews    map[string]*oauthzv1.ResourceAccessReviewResponse
+}
+
+func newConnectionNSAuthz(tenants []v1alpha1.DBaaSTenant) *connectionNSAuthz {
+	return &connectionNSAuthz{
+		tenants: tenants,
+		reviews: map[string]*oauthzv1.ResourceAccessReviewResponse{},
+	}
+}
+
+// returns the existing namespaces, other than its own, allowed to reference the inventory
+func (r *DBaaSAuthzReconciler) getAllowedConnectionNamespaces(ctx context.Context, inventory v1alpha1.DBaaSInventory, nsAuthz *connectionNSAuthz) ([]string, error) {
+	validNamespaces, selectors := getConnectionNSRules(&inventory, nsAuthz.tenants)
+	wildcard := contains(validNamespaces, "*")
+
+	var allowedNamespaces []string
+	if wildcard || len(selectors) > 0 {
+		if nsAuthz.namespaces == nil {
+			var namespaceList corev1.NamespaceList
+			if err := r.List(ctx, &namespaceList); err != nil {
+				return nil, err
+			}
+			nsAuthz.namespaces = &namespaceList
+		}
+		for _, namespace := range nsAuthz.namespaces.Items {
+			matches := wildcard || contains(validNamespaces, namespace.Name)
+			if !matches {
+				var err error
+				if matches, err = matchesAnySelector(selectors, namespace.Labels); err != nil {
+					return nil, err
+				}
+			}
+			if matches {
+				allowedNamespaces = append(allowedNamespaces, namespace.Name)
+			}
+		}
+	} else {
+		for _, name := range uniqueStrSlice(validNamespaces) {
+			if err := r.Get(ctx, types.NamespacedName{Name: name}, &corev1.Namespace{}); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			allowedNamespaces = append(allowedNamespaces, name)
+		}
+	}
+
+	// developers in the inventory namespace are covered by the developer authz
+	return removeFromSlice([]string{inventory.Namespace}, allowedNamespaces), nil
+}
+
+// ResourceAccessReview for Connection Namespace Developer Authz
+// return users/groups who can create connections in the namespaces, other than its own, allowed to reference the inventory
+func (r *DBaaSAuthzReconciler) getConnectionNSAuthz(ctx context.Context, inventory v1alpha1.DBaaSInventory, nsAuthz *connectionNSAuthz) (*oauthzv1.ResourceAccessReviewResponse, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	allowedNamespaces, err := r.getAllowedConnectionNamespaces(ctx, inventory, nsAuthz)
+	if err != nil {
+		logger.Error(err, "Error resolving the connection namespaces for inventory authz")
+		return nil, err
+	}
+
+	var users, groups []string
+	for _, namespace := range allowedNamespaces {
+		connectionResponse, ok := nsAuthz.reviews[namespace]
+		if !ok {
+			// connection access review
+			action := oauthzv1.Action{
+				Resource:  "dbaasconnections",
+				Verb:      "create",
+				Namespace: namespace,
+				Group:     v1alpha1.GroupVersion.Group,
+				Version:   v1alpha1.GroupVersion.Version,
+			}
+			if connectionResponse, err = r.AuthzBackend.ResourceAccessReview(ctx, action); err != nil {
+				logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+				return nil, err
+			}
+			nsAuthz.reviews[namespace] = connectionResponse
+		}
+		users = append(users, connectionResponse.UsersSlice...)
+		groups = append(groups, connectionResponse.GroupsSlice...)
+	}
+
+	return &oauthzv1.ResourceAccessReviewResponse{
+		UsersSlice:  uniqueStrSlice(users),
+		GroupsSlice: uniqueStrSlice(groups),
+	}, nil
+}
+
+// Reconcile inventory to ensure developers in its connection namespaces can read it. When the access reviews fail,
+// the existing RBAC objects are left untouched, keeping the last known-good subjects until a retry succeeds.
+func (r *DBaaSAuthzReconciler) reconcileInventoryRbacObjs(ctx context.Context, inventory v1alpha1.DBaaSInventory, nsAuthz *connectionNSAuthz) error {
+	connectionNSAuthz, err := r.getConnectionNSAuthz(ctx, inventory, nsAuthz)
+	if err != nil {
+		return err
+	}
+
+	role, roleBinding := inventoryRbacObjs(inventory, connectionNSAuthz)
+	var roleObj rbacv1.Role
+	if exists, err := r.createRbacObj(&role, &roleObj, &inventory, ctx); err != nil {
+		return err
+	} else if exists {
+		if !reflect.DeepEqual(role.Rules, roleObj.Rules) {
+			roleObj.Rules = role.Rules
+			if err := r.updateIfOwned(ctx, &inventory, &roleObj); err != nil {
+				return err
+			}
+		}
+	}
+	var roleBindingObj rbacv1.RoleBinding
+	if exists, err := r.createRbacObj(&roleBinding, &roleBindingObj, &inventory, ctx); err != nil {
+		return err
+	} else if exists {
+		if !reflect.DeepEqual(roleBinding.RoleRef, roleBindingObj.RoleRef) ||
+			!reflect.DeepEqual(roleBinding.Subjects, roleBindingObj.Subjects) {
+			roleBindingObj.RoleRef = roleBinding.RoleRef
+			roleBindingObj.Subjects = roleBinding.Subjects
+			if err := r.updateIfOwned(ctx, &inventory, &roleBindingObj); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Update the tenant status with the resolved authz subjects, inventory state and the result of the RBAC reconcile
+func (r *DBaaSAuthzReconciler) updateTenantStatus(ctx context.Context, tenant v1alpha1.DBaaSTenant, inventoryList v1alpha1.DBaaSInventoryList,
+	serviceAdminAuthz, developerAuthz *oauthzv1.ResourceAccessReviewResponse, reviewErr, rbacErr error) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	connectionNamespaces, err := r.getConnectionNamespaces(ctx, tenant.Spec.InventoryNamespace, inventoryList)
+	if err != nil {
+		logger.Error(err, "Error resolving the connection namespaces of the DBaaS Tenant", "DBaaS Tenant", tenant.Name)
+		return err
+	}
+	status := tenant.Status.DeepCopy()
+	setTenantStatus(status, tenant, inventoryList, connectionNamespaces, serviceAdminAuthz, developerAuthz, reviewErr, rbacErr)
+	if reflect.DeepEqual(status, &tenant.Status) {
+		return nil
+	}
+	tenant.Status = *status
+	if err := r.Client.Status().Update(ctx, &tenant); err != nil {
+		if !errors.IsConflict(err) {
+			logger.Error(err, "Error updating the DBaaS Tenant resource status", "DBaaS Tenant", tenant.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// returns the effective connection namespaces of the inventories of a tenant namespace: the inventory namespace,
+// and the namespaces allowed by each inventory, whose settings override those of the tenants, or by the tenants when
+// there are no inventories. The namespaces matching the selectors are listed, and "*" alone is returned when all
+// namespaces are allowed.
+func (r *DBaaSAuthzReconciler) getConnectionNamespaces(ctx context.Context, namespace string, inventoryList v1alpha1.DBaaSInventoryList) ([]string, error) {
+	tenantList, err := r.tenantListByInventoryNS(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	connectionNamespaces := []string{namespace}
+	var selectors []*metav1.LabelSelector
+	addConnectionNSRules := func(inventory *v1alpha1.DBaaSInventory) {
+		validNamespaces, inventorySelectors := getConnectionNSRules(inventory, tenantList.Items)
+		connectionNamespaces = append(connectionNamespaces, validNamespaces...)
+		selectors = append(selectors, inventorySelectors...)
+	}
+	if len(inventoryList.Items) == 0 {
+		addConnectionNSRules(&v1alpha1.DBaaSInventory{})
+	}
+	for i := range inventoryList.Items {
+		addConnectionNSRules(&inventoryList.Items[i])
+	}
+	if contains(connectionNamespaces, "*") {
+		return []string{"*"}, nil
+	}
+
+	if len(selectors) > 0 {
+		var namespaceList corev1.NamespaceList
+		if err := r.List(ctx, &namespaceList); err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaceList.Items {
+			matches, err := matchesAnySelector(selectors, ns.Labels)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				connectionNamespaces = append(connectionNamespaces, ns.Name)
+			}
+		}
+	}
+	return sortedStrSlice(connectionNamespaces), nil
+}
+
+// sets the authz, inventory, connection namespaces and condition fields of a tenant status.
+// The last known-good subjects are kept when the access reviews failed.
+func setTenantStatus(status *v1alpha1.DBaaSTenantStatus, tenant v1alpha1.DBaaSTenant, inventoryList v1alpha1.DBaaSInventoryList,
+	connectionNamespaces []string, serviceAdminAuthz, developerAuthz *oauthzv1.ResourceAccessReviewResponse, reviewErr, rbacErr error) {
+	if reviewErr == nil {
+		status.ServiceAdmins = v1alpha1.DBaaSTenantSubjects{
+			Users:  sortedStrSlice(serviceAdminAuthz.UsersSlice),
+			Groups: sortedStrSlice(serviceAdminAuthz.GroupsSlice),
+		}
+		status.Developers = v1alpha1.DBaaSTenantSubjects{
+			Users:  sortedStrSlice(developerAuthz.UsersSlice),
+			Groups: sortedStrSlice(developerAuthz.GroupsSlice),
+		}
+	}
+
+	status.Inventories = v1alpha1.DBaaSTenantInventoryCounts{}
+	for _, inventory := range inventoryList.Items {
+		status.Inventories.Total++
+		if apimeta.IsStatusConditionTrue(inventory.Status.Conditions, v1alpha1.DBaaSInventoryReadyType) {
+			status.Inventories.Ready++
+		} else {
+			status.Inventories.NotReady++
+		}
+	}
+	status.ConnectionNamespaces = connectionNamespaces
+
+	authzCond := metav1.Condition{
+		Type:               v1alpha1.DBaaSTenantAuthzSyncedType,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.Ready,
+		Message:            v1alpha1.MsgAuthzSynced,
+		ObservedGeneration: tenant.Generation,
+	}
+	degradedCond := metav1.Condition{
+		Type:               v1alpha1.DBaaSTenantDegradedType,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.NotDegraded,
+		Message:            v1alpha1.MsgTenantNotDegraded,
+		ObservedGeneration: tenant.Generation,
+	}
+	if reviewErr != nil {
+		authzCond.Status = metav1.ConditionFalse
+		authzCond.Reason = v1alpha1.AccessReviewFailed
+		authzCond.Message = reviewErr.Error()
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = v1alpha1.AccessReviewFailed
+		degradedCond.Message = v1alpha1.MsgAccessReviewFailed + ": " + reviewErr.Error()
+	} else if rbacErr != nil {
+		authzCond.Status = metav1.ConditionFalse
+		authzCond.Reason = v1alpha1.AuthzSyncFailed
+		authzCond.Message = rbacErr.Error()
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = v1alpha1.AuthzSyncFailed
+		degradedCond.Message = rbacErr.Error()
+	}
+	apimeta.SetStatusCondition(&status.Conditions, authzCond)
+	apimeta.SetStatusCondition(&status.Conditions, degradedCond)
+
+	// failed access reviews don't affect readiness, as the last known-good RBAC is kept
+	readyCond := metav1.Condition{
+		Type:               v1alpha1.DBaaSTenantReadyType,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.Ready,
+		Message:            v1alpha1.MsgTenantReady,
+		ObservedGeneration: tenant.Generation,
+	}
+	if rbacErr != nil {
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = v1alpha1.AuthzSyncFailed
+		readyCond.Message = rbacErr.Error()
+	} else if status.Inventories.Ready == 0 {
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = v1alpha1.NoReadyInventories
+		readyCond.Message = v1alpha1.MsgNoReadyInventories
+	}
+	apimeta.SetStatusCondition(&status.Conditions, readyCond)
+}
+
 // create RBAC object, return true if already exists
 func (r *DBaaSAuthzReconciler) createRbacObj(newObj, getObj, owner client.Object, ctx context.Context) (exists bool, err error) {
 	name := newObj.GetName()
@@ -245,6 +553,41 @@ func tenantRbacObjs(tenant v1alpha1.DBaaSTenant, serviceAdminAuthz, developerAut
 	return clusterRole, clusterRoleBinding
 }
 
+// gets rbac objects granting read access to an inventory for developers in its connection namespaces
+func inventoryRbacObjs(inventory v1alpha1.DBaaSInventory, connectionNSAuthz *oauthzv1.ResourceAccessReviewResponse) (rbacv1.Role, rbacv1.RoleBinding) {
+	role := rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dbaas-" + inventory.Name + "-inventory-viewer",
+			Namespace: inventory.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{v1alpha1.GroupVersion.Group},
+				Resources:     []string{"dbaasinventories", "dbaasinventories/status"},
+				ResourceNames: []string{inventory.Name},
+				Verbs:         []string{"get"},
+			},
+		},
+	}
+	role.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("Role"))
+
+	roleBinding := rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      role.Name + "s",
+			Namespace: inventory.Namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.SchemeGroupVersion.Group,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+	}
+	roleBinding.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("RoleBinding"))
+	roleBinding.Subjects = getSubjects(connectionNSAuthz.UsersSlice, connectionNSAuthz.GroupsSlice, "")
+
+	return role, roleBinding
+}
+
 // verify no edit or list permissions are assigned to a role
 func hasNoEditOrListVerbs(roleObj client.Object) bool {
 	var verbs []string
@@ -0,0 +1,253 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	oauthzv1 "github.com/openshift/api/authorization/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// the subjects with a level of access to an inventory, and the actions granting it
+type accessReportLevel struct {
+	level   v1alpha1.DBaaSAccessLevel
+	authz   *oauthzv1.ResourceAccessReviewResponse
+	actions []oauthzv1.Action
+}
+
+// Reconcile the access report of an inventory, listing the subjects with access to it.
+// The report is only updated when the access it lists has changed, to keep its timestamp meaningful, or when its status
+// was modified by another writer, as reports are read-only.
+func (r *DBaaSAuthzReconciler) reconcileAccessReport(ctx context.Context, inventory v1alpha1.DBaaSInventory, tenants []v1alpha1.DBaaSTenant) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	levels, err := r.getAccessReportLevels(ctx, inventory, tenants)
+	if err != nil {
+		return err
+	}
+	entries, err := r.getAccessReportEntries(ctx, levels)
+	if err != nil {
+		logger.Error(err, "Error resolving the RBAC bindings granting access", "DBaaS Inventory", inventory.Name)
+		return err
+	}
+	var tenantNames []string
+	for _, tenant := range tenants {
+		tenantNames = append(tenantNames, tenant.Name)
+	}
+	tenantNames = sortedStrSlice(tenantNames)
+
+	var report v1alpha1.DBaaSAccessReport
+	if err := r.Get(ctx, types.NamespacedName{Name: inventory.Name, Namespace: inventory.Namespace}, &report); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Error getting the DBaaS Access Report", "DBaaS Inventory", inventory.Name)
+			return err
+		}
+		report = v1alpha1.DBaaSAccessReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventory.Name,
+				Namespace: inventory.Namespace,
+			},
+		}
+		if err := r.createOwnedObject(&report, &inventory, ctx); err != nil {
+			logger.Error(err, "Error creating the DBaaS Access Report", "DBaaS Inventory", inventory.Name)
+			return err
+		}
+	}
+
+	if report.Status.GeneratedAt != nil &&
+		reflect.DeepEqual(report.Status.Tenants, tenantNames) &&
+		reflect.DeepEqual(report.Status.Entries, entries) {
+		return nil
+	}
+	now := metav1.Now()
+	report.Status = v1alpha1.DBaaSAccessReportStatus{
+		GeneratedAt: &now,
+		Tenants:     tenantNames,
+		Entries:     entries,
+	}
+	if err := r.Client.Status().Update(ctx, &report); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Access Report modified, retry reconciling")
+		} else {
+			logger.Error(err, "Error updating the DBaaS Access Report status", "DBaaS Inventory", inventory.Name)
+		}
+		return err
+	}
+	logger.Info("DBaaS Access Report generated", "DBaaS Inventory", inventory.Name)
+
+	return nil
+}
+
+// reviews the subjects with each level of access to an inventory
+func (r *DBaaSAuthzReconciler) getAccessReportLevels(ctx context.Context, inventory v1alpha1.DBaaSInventory, tenants []v1alpha1.DBaaSTenant) ([]accessReportLevel, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// admins can create both inventory and secret objects in the inventory namespace
+	adminAuthz, err := r.getServiceAdminAuthz(ctx, inventory.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	inventoryCreateAction := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "create",
+		Namespace: inventory.Namespace,
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
+	}
+	secretCreateAction := oauthzv1.Action{
+		Resource:  "secrets",
+		Verb:      "create",
+		Namespace: inventory.Namespace,
+		Group:     corev1.SchemeGroupVersion.Group,
+		Version:   corev1.SchemeGroupVersion.Version,
+	}
+
+	// developers can list inventories in the inventory namespace, or get the inventory
+	developerAuthz, err := r.getDeveloperAuthz(ctx, inventory.Namespace, v1alpha1.DBaaSInventoryList{Items: []v1alpha1.DBaaSInventory{inventory}})
+	if err != nil {
+		return nil, err
+	}
+	inventoryListAction := oauthzv1.Action{
+		Resource:  "dbaasinventories",
+		Verb:      "list",
+		Namespace: inventory.Namespace,
+		Group:     v1alpha1.GroupVersion.Group,
+		Version:   v1alpha1.GroupVersion.Version,
+	}
+	inventoryGetAction := inventoryListAction
+	inventoryGetAction.Verb = "get"
+	inventoryGetAction.ResourceName = inventory.Name
+
+	// tenant viewers can get any of the tenants of the inventory namespace
+	var viewerUsers, viewerGroups []string
+	var tenantGetActions []oauthzv1.Action
+	for _, tenant := range tenants {
+		action := oauthzv1.Action{
+			Resource:     "dbaastenants",
+			Verb:         "get",
+			ResourceName: tenant.Name,
+			Group:        v1alpha1.GroupVersion.Group,
+			Version:      v1alpha1.GroupVersion.Version,
+		}
+		tenantResponse, err := r.AuthzBackend.ResourceAccessReview(ctx, action)
+		if err != nil {
+			logger.Error(err, "Error creating ResourceAccessReview", "Action", action)
+			return nil, err
+		}
+		viewerUsers = append(viewerUsers, tenantResponse.UsersSlice...)
+		viewerGroups = append(viewerGroups, tenantResponse.GroupsSlice...)
+		tenantGetActions = append(tenantGetActions, action)
+	}
+	viewerAuthz := &oauthzv1.ResourceAccessReviewResponse{
+		UsersSlice:  uniqueStrSlice(viewerUsers),
+		GroupsSlice: uniqueStrSlice(viewerGroups),
+	}
+
+	return []accessReportLevel{
+		{level: v1alpha1.AccessLevelAdmin, authz: adminAuthz, actions: []oauthzv1.Action{inventoryCreateAction, secretCreateAction}},
+		{level: v1alpha1.AccessLevelDeveloper, authz: developerAuthz, actions: []oauthzv1.Action{inventoryListAction, inventoryGetAction}},
+		{level: v1alpha1.AccessLevelTenantViewer, authz: viewerAuthz, actions: tenantGetActions},
+	}, nil
+}
+
+// lists the subjects of each access level, along with the RBAC bindings granting them access
+func (r *DBaaSAuthzReconciler) getAccessReportEntries(ctx context.Context, levels []accessReportLevel) ([]v1alpha1.DBaaSAccessReportEntry, error) {
+	rbacBackend := r.getGrantsBackend()
+
+	var entries []v1alpha1.DBaaSAccessReportEntry
+	for _, level := range levels {
+		var bindings []authzBinding
+		for _, action := range level.actions {
+			actionBindings, err := rbacBackend.getAllowingBindings(ctx, action)
+			if err != nil {
+				return nil, err
+			}
+			bindings = append(bindings, actionBindings...)
+		}
+		for _, subject := range getSubjects(sortedStrSlice(level.authz.UsersSlice), sortedStrSlice(level.authz.GroupsSlice), "") {
+			entries = append(entries, v1alpha1.DBaaSAccessReportEntry{
+				AccessLevel: level.level,
+				Subject:     subject,
+				GrantedBy:   getGrants(subject, bindings),
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// resolves the RBAC bindings granting an action
+type authzGrantsResolver interface {
+	getAllowingBindings(ctx context.Context, action oauthzv1.Action) ([]authzBinding, error)
+}
+
+var _ authzGrantsResolver = &CachedAuthzBackend{}
+var _ authzGrantsResolver = &RBACAuthzBackend{}
+
+// returns the resolver of the bindings granting access: the cached backend, whose bindings are only resolved again
+// when the RBAC objects change, or else the configured backend when it is RBAC based, or a backend reading the RBAC
+// objects from the API server
+func (r *DBaaSAuthzReconciler) getGrantsBackend() authzGrantsResolver {
+	switch backend := r.AuthzBackend.(type) {
+	case *CachedAuthzBackend:
+		return backend
+	case *RBACAuthzBackend:
+		return backend
+	}
+	return &RBACAuthzBackend{Reader: r.apiReader}
+}
+
+// returns the sorted unique grants of the bindings binding the subject, or nil if there are none
+func getGrants(subject rbacv1.Subject, bindings []authzBinding) []v1alpha1.DBaaSAccessGrant {
+	var grants []v1alpha1.DBaaSAccessGrant
+	for _, binding := range bindings {
+		for _, bindingSubject := range binding.subjects {
+			if bindingSubject.Kind == subject.Kind && bindingSubject.Name == subject.Name &&
+				(subject.Kind != rbacv1.ServiceAccountKind || bindingSubject.Namespace == subject.Namespace) {
+				grants = append(grants, binding.grant)
+				break
+			}
+		}
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].Kind != grants[j].Kind {
+			return grants[i].Kind < grants[j].Kind
+		}
+		if grants[i].Namespace != grants[j].Namespace {
+			return grants[i].Namespace < grants[j].Namespace
+		}
+		return grants[i].Name < grants[j].Name
+	})
+
+	var uniqueGrants []v1alpha1.DBaaSAccessGrant
+	for i, grant := range grants {
+		if i == 0 || !reflect.DeepEqual(grant, grants[i-1]) {
+			uniqueGrants = append(uniqueGrants, grant)
+		}
+	}
+	return uniqueGrants
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestGetGrants(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	roleBindingGrant := v1alpha1.DBaaSAccessGrant{
+		Kind:      "RoleBinding",
+		Name:      "admins",
+		Namespace: "test",
+		RoleRef:   rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+	}
+	clusterRoleBindingGrant := v1alpha1.DBaaSAccessGrant{
+		Kind:    "ClusterRoleBinding",
+		Name:    "cluster-admins",
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+	}
+	bindings := []authzBinding{
+		{
+			grant: roleBindingGrant,
+			subjects: []rbacv1.Subject{
+				getSubject("user1", "", rbacv1.UserKind),
+				getSubject("sa1", "test", rbacv1.ServiceAccountKind),
+			},
+		},
+		{
+			grant: clusterRoleBindingGrant,
+			subjects: []rbacv1.Subject{
+				getSubject("user1", "", rbacv1.UserKind),
+				getSubject("group1", "", rbacv1.GroupKind),
+			},
+		},
+		// the same binding allowing several actions
+		{
+			grant:    roleBindingGrant,
+			subjects: []rbacv1.Subject{getSubject("user1", "", rbacv1.UserKind)},
+		},
+	}
+
+	// grants are sorted and unique
+	Expect(getGrants(getSubject("user1", "", rbacv1.UserKind), bindings)).To(Equal([]v1alpha1.DBaaSAccessGrant{clusterRoleBindingGrant, roleBindingGrant}))
+	Expect(getGrants(getSubject("group1", "", rbacv1.GroupKind), bindings)).To(Equal([]v1alpha1.DBaaSAccessGrant{clusterRoleBindingGrant}))
+
+	// subjects are matched by kind, and by namespace for service accounts
+	Expect(getGrants(getSubject("sa1", "test", rbacv1.ServiceAccountKind), bindings)).To(Equal([]v1alpha1.DBaaSAccessGrant{roleBindingGrant}))
+	Expect(getGrants(getSubject("sa1", "other", rbacv1.ServiceAccountKind), bindings)).To(BeNil())
+	Expect(getGrants(getSubject("user1", "", rbacv1.GroupKind), bindings)).To(BeNil())
+}
@@ -413,7 +413,7 @@ func assertProviderResourceSpecUpdated(object client.Object, providerResourceKin
 			case *v1alpha1.DBaaSConnection:
 				v.Spec = *DBaaSResourceSpec.(*v1alpha1.DBaaSConnectionSpec)
 			case *v1alpha1.DBaaSInstance:
-				v.Spec = *DBaaSResourceSpec.(*v1alpha1.DBaaSInstanceSpec)
+				v.Spec.DBaaSInstanceSpec = *DBaaSResourceSpec.(*v1alpha1.DBaaSInstanceSpec)
 			default:
 				Fail("invalid test object")
 			}
@@ -493,6 +493,20 @@ func (c *spyctrl) watched(w *watchable) bool {
 	return false
 }
 
+func (c *spyctrl) watchCount(w *watchable) int {
+	c.mutex.Lock()
+	values := c.values
+	c.mutex.Unlock()
+
+	count := 0
+	for _, value := range values {
+		if reflect.DeepEqual(w, value) {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *spyctrl) delete(w *watchable) bool {
 	c.mutex.Lock()
 
@@ -515,6 +529,8 @@ func (c *spyctrl) Watch(src source.Source, evthdler handler.EventHandler, prct .
 	switch s := src.(type) {
 	case *source.Kind:
 		w.source = s.Type
+	case *providerSource:
+		w.source = s.Type
 	default:
 		Fail("unexpected source type")
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -16,18 +17,18 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
-	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // InstallNamespaceEnvVar is the constant for env variable INSTALL_NAMESPACE
@@ -53,6 +54,23 @@ var ignoreCreateEvents = predicate.Funcs{
 		return true
 	},
 }
+var namespaceLabelsChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
 var ignoreAllEvents = predicate.Funcs{
 	CreateFunc: func(e event.CreateEvent) bool {
 		return false
@@ -82,26 +100,16 @@ func (r *DBaaSReconciler) getDBaaSProvider(providerName string, ctx context.Cont
 	return provider, nil
 }
 
-func (r *DBaaSReconciler) watchDBaaSProviderObject(ctrl controller.Controller, object runtime.Object, providerObjectKind string) error {
-	providerObject := unstructured.Unstructured{}
-	providerObject.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   v1alpha1.GroupVersion.Group,
-		Version: v1alpha1.GroupVersion.Version,
-		Kind:    providerObjectKind,
-	})
-	err := ctrl.Watch(
-		&source.Kind{
-			Type: &providerObject,
-		},
-		&handler.EnqueueRequestForOwner{
-			OwnerType:    object,
-			IsController: true,
-		},
-	)
-	if err != nil {
-		return err
+// getDBaaSPlatform returns the DBaaSPlatform of the install namespace, or nil if there is none
+func (r *DBaaSReconciler) getDBaaSPlatform(ctx context.Context) (*v1alpha1.DBaaSPlatform, error) {
+	platformList := &v1alpha1.DBaaSPlatformList{}
+	if err := r.List(ctx, platformList, client.InNamespace(r.InstallNamespace)); err != nil {
+		return nil, err
 	}
-	return nil
+	if len(platformList.Items) == 0 {
+		return nil, nil
+	}
+	return &platformList.Items[0], nil
 }
 
 func (r *DBaaSReconciler) createProviderObject(object client.Object, providerObjectKind string) *unstructured.Unstructured {
@@ -171,21 +179,82 @@ func (r *DBaaSReconciler) isValidConnectionNS(ctx context.Context, namespace str
 	if namespace == inventory.Namespace {
 		return true, nil
 	}
-	validNamespaces := inventory.Spec.ConnectionNamespaces
-	if len(validNamespaces) == 0 {
+	var tenants []v1alpha1.DBaaSTenant
+	if len(inventory.Spec.ConnectionNamespaces) == 0 && inventory.Spec.ConnectionNamespaceSelector == nil {
 		tenantList, err := r.tenantListByInventoryNS(ctx, inventory.Namespace)
 		if err != nil {
 			return false, err
 		}
-		for _, tenant := range tenantList.Items {
-			validNamespaces = append(validNamespaces, tenant.Spec.ConnectionNamespaces...)
-		}
+		tenants = tenantList.Items
 	}
+	validNamespaces, selectors := getConnectionNSRules(inventory, tenants)
 	// valid if all namespaces are supported via wildcard
-	if contains(validNamespaces, "*") {
+	if contains(validNamespaces, "*") || contains(validNamespaces, namespace) {
 		return true, nil
 	}
-	return contains(validNamespaces, namespace), nil
+	if len(selectors) == 0 {
+		return false, nil
+	}
+
+	// valid if the namespace labels match any selector
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return matchesAnySelector(selectors, ns.Labels)
+}
+
+// returns the connection namespaces and namespace selectors of an inventory, which default to those of the tenants
+// of its namespace
+func getConnectionNSRules(inventory *v1alpha1.DBaaSInventory, tenants []v1alpha1.DBaaSTenant) ([]string, []*metav1.LabelSelector) {
+	validNamespaces := inventory.Spec.ConnectionNamespaces
+	var selectors []*metav1.LabelSelector
+	if inventory.Spec.ConnectionNamespaceSelector != nil {
+		selectors = append(selectors, inventory.Spec.ConnectionNamespaceSelector)
+	}
+	if len(validNamespaces) == 0 && len(selectors) == 0 {
+		for _, tenant := range tenants {
+			validNamespaces = append(validNamespaces, tenant.Spec.ConnectionNamespaces...)
+			if tenant.Spec.ConnectionNamespaceSelector != nil {
+				selectors = append(selectors, tenant.Spec.ConnectionNamespaceSelector)
+			}
+		}
+	}
+	return validNamespaces, selectors
+}
+
+// checks if namespace labels match any selector
+func matchesAnySelector(selectors []*metav1.LabelSelector, nsLabels map[string]string) (bool, error) {
+	for _, labelSelector := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return false, err
+		}
+		if selector.Matches(labels.Set(nsLabels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// returns a map function which requests all objects of a list type in a namespace,
+// so they are re-evaluated against connection namespace selectors
+func (r *DBaaSReconciler) namespaceObjectsMapFunc(newListFn func() client.ObjectList) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		list := newListFn()
+		if err := r.List(context.Background(), list, client.InNamespace(o.GetName())); err != nil {
+			return nil
+		}
+		var requests []reconcile.Request
+		_ = apimeta.EachListItem(list, func(obj runtime.Object) error {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj.(client.Object))})
+			return nil
+		})
+		return requests
+	}
 }
 
 func (r *DBaaSReconciler) reconcileProviderResource(providerName string, DBaaSObject client.Object,
@@ -419,6 +488,16 @@ func uniqueStrSlice(input []string) []string {
 	return u
 }
 
+// returns a sorted unique copy of the provided slice, or nil if it's empty
+func sortedStrSlice(input []string) []string {
+	if len(input) == 0 {
+		return nil
+	}
+	s := uniqueStrSlice(input)
+	sort.Strings(s)
+	return s
+}
+
 // returns a subset of the provided slices, after removing certain entries
 func removeFromSlice(entriesToRemove, fromSlice []string) []string {
 	r := []string{}
@@ -186,28 +186,6 @@ var _ = Describe("Provider object MutateFn", func() {
 	})
 })
 
-var _ = Describe("Watch DBaaS provider Object", func() {
-	It("should invoke controller watch with correctly input", func() {
-		source := &unstructured.Unstructured{}
-		source.SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   v1alpha1.GroupVersion.Group,
-			Version: v1alpha1.GroupVersion.Version,
-			Kind:    "test-kind",
-		})
-		owner := &v1alpha1.DBaaSInventory{}
-		spyController := newSpyController(nil)
-
-		err := dRec.watchDBaaSProviderObject(spyController, owner, "test-kind")
-		Expect(err).NotTo(HaveOccurred())
-		Eventually(func() bool {
-			return spyController.watched(&watchable{
-				source: source,
-				owner:  owner,
-			})
-		}, timeout).Should(BeTrue())
-	})
-})
-
 var _ = Describe("list tenants by inventory namespace", func() {
 	Context("after creating DBaaSTenants", func() {
 		ns := "test-namespace"
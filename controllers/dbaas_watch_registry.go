@@ -0,0 +1,243 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ProviderWatchRegistryPath is the metrics server path listing the active provider watches, for debugging
+const ProviderWatchRegistryPath = "/debug/provider-watches"
+
+// ProviderWatchRegistry tracks the watches of provider CRs by the DBaaS controllers. Each provider kind is watched
+// at most once, through its own informer, which is stopped when no provider references the kind anymore.
+type ProviderWatchRegistry struct {
+	// Config is used to create the informers of the watched kinds
+	Config *rest.Config
+	// Scheme is used to create the informers of the watched kinds
+	Scheme *runtime.Scheme
+	// Mapper maps the watched kinds to their resources
+	Mapper meta.RESTMapper
+
+	mutex   sync.Mutex
+	ctx     context.Context
+	watches map[schema.GroupVersionKind]*providerWatch
+}
+
+// ProviderWatch is a provider kind to be watched by a DBaaS controller
+type ProviderWatch struct {
+	// Controller reconciling the owner of the provider objects
+	Controller controller.Controller
+	// Owner is the type of the DBaaS objects owning the provider objects
+	Owner runtime.Object
+	// Kind of the provider objects
+	Kind string
+}
+
+// ActiveProviderWatch describes a running watch, for debugging
+type ActiveProviderWatch struct {
+	GroupVersionKind string   `json:"groupVersionKind"`
+	Owner            string   `json:"owner"`
+	Providers        []string `json:"providers"`
+}
+
+// a running watch, and the providers referencing it
+type providerWatch struct {
+	owner     runtime.Object
+	providers map[string]bool
+	cancel    context.CancelFunc
+}
+
+var _ manager.Runnable = &ProviderWatchRegistry{}
+var _ manager.LeaderElectionRunnable = &ProviderWatchRegistry{}
+
+// Start implements manager.Runnable, all watches are stopped along with the manager
+func (r *ProviderWatchRegistry) Start(ctx context.Context) error {
+	r.mutex.Lock()
+	r.ctx = ctx
+	r.mutex.Unlock()
+
+	<-ctx.Done()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for gvk, watch := range r.watches {
+		watch.cancel()
+		delete(r.watches, gvk)
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the registry must be started before the controllers
+func (r *ProviderWatchRegistry) NeedLeaderElection() bool {
+	return false
+}
+
+// SetProviderWatches sets the kinds watched on behalf of a provider. Watches not yet running are started, and
+// watches no longer referenced by any provider are stopped. Passing no watches releases all watches of the provider.
+func (r *ProviderWatchRegistry) SetProviderWatches(provider string, watches []ProviderWatch) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.watches == nil {
+		r.watches = map[schema.GroupVersionKind]*providerWatch{}
+	}
+
+	desired := map[schema.GroupVersionKind]ProviderWatch{}
+	for _, watch := range watches {
+		desired[providerGVK(watch.Kind)] = watch
+	}
+
+	// release the watches of kinds no longer used by the provider
+	for gvk, watch := range r.watches {
+		if _, ok := desired[gvk]; ok || !watch.providers[provider] {
+			continue
+		}
+		delete(watch.providers, provider)
+		if len(watch.providers) == 0 {
+			watch.cancel()
+			delete(r.watches, gvk)
+		}
+	}
+
+	for gvk, watch := range desired {
+		if existing, ok := r.watches[gvk]; ok {
+			if reflect.TypeOf(existing.owner) != reflect.TypeOf(watch.Owner) {
+				return fmt.Errorf("kind %s is already watched for %T objects", watch.Kind, existing.owner)
+			}
+			existing.providers[provider] = true
+			continue
+		}
+		cancel, err := r.startWatch(gvk, watch)
+		if err != nil {
+			return err
+		}
+		r.watches[gvk] = &providerWatch{
+			owner:     watch.Owner,
+			providers: map[string]bool{provider: true},
+			cancel:    cancel,
+		}
+	}
+
+	return nil
+}
+
+// ActiveWatches lists the running watches, sorted by kind
+func (r *ProviderWatchRegistry) ActiveWatches() []ActiveProviderWatch {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var activeWatches []ActiveProviderWatch
+	for gvk, watch := range r.watches {
+		var providers []string
+		for provider := range watch.providers {
+			providers = append(providers, provider)
+		}
+		activeWatches = append(activeWatches, ActiveProviderWatch{
+			GroupVersionKind: gvk.String(),
+			Owner:            fmt.Sprintf("%T", watch.owner),
+			Providers:        sortedStrSlice(providers),
+		})
+	}
+	sort.Slice(activeWatches, func(i, j int) bool {
+		return activeWatches[i].GroupVersionKind < activeWatches[j].GroupVersionKind
+	})
+	return activeWatches
+}
+
+// ServeHTTP lists the running watches as JSON
+func (r *ProviderWatchRegistry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.ActiveWatches()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// starts watching a provider kind through a dedicated informer, returns the function stopping the watch
+func (r *ProviderWatchRegistry) startWatch(gvk schema.GroupVersionKind, watch ProviderWatch) (context.CancelFunc, error) {
+	if r.ctx == nil {
+		return nil, fmt.Errorf("the provider watch registry is not started")
+	}
+
+	watchCache, err := cache.New(r.Config, cache.Options{Scheme: r.Scheme, Mapper: r.Mapper})
+	if err != nil {
+		return nil, err
+	}
+	providerObject := &unstructured.Unstructured{}
+	providerObject.SetGroupVersionKind(gvk)
+	src := &providerSource{Kind: &source.Kind{Type: providerObject}}
+	if err := src.InjectCache(watchCache); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	src.ctx = ctx
+	go func() {
+		// only returns once the watch is stopped
+		_ = watchCache.Start(ctx)
+	}()
+	if err := watch.Controller.Watch(
+		src,
+		&handler.EnqueueRequestForOwner{
+			OwnerType:    watch.Owner,
+			IsController: true,
+		},
+	); err != nil {
+		cancel()
+		return nil, err
+	}
+	return cancel, nil
+}
+
+// returns the group version kind of a provider kind
+func providerGVK(kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   v1alpha1.GroupVersion.Group,
+		Version: v1alpha1.GroupVersion.Version,
+		Kind:    kind,
+	}
+}
+
+// a kind source bound to the lifetime of its watch, rather than of the controller
+type providerSource struct {
+	*source.Kind
+	ctx context.Context
+}
+
+// Start starts the kind source with the watch context
+func (s *providerSource) Start(_ context.Context, h handler.EventHandler, q workqueue.RateLimitingInterface, prct ...predicate.Predicate) error {
+	return s.Kind.Start(s.ctx, h, q, prct...)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Provider watch registry", func() {
+	var registry *ProviderWatchRegistry
+	var cancel context.CancelFunc
+
+	BeforeEach(func() {
+		registry = &ProviderWatchRegistry{
+			Config: watchRegistry.Config,
+			Scheme: watchRegistry.Scheme,
+			Mapper: watchRegistry.Mapper,
+		}
+		var registryCtx context.Context
+		registryCtx, cancel = context.WithCancel(ctx)
+		go func() {
+			defer GinkgoRecover()
+			Expect(registry.Start(registryCtx)).To(Succeed())
+		}()
+		Eventually(func() error {
+			return registry.SetProviderWatches("test-provider", nil)
+		}, timeout).Should(Succeed())
+	})
+	AfterEach(func() { cancel() })
+
+	It("should watch each kind once, and stop watches no provider references", func() {
+		source := &unstructured.Unstructured{}
+		source.SetGroupVersionKind(providerGVK("test-kind"))
+		owner := &v1alpha1.DBaaSInventory{}
+		spyController := newSpyController(nil)
+		watch := ProviderWatch{Controller: spyController, Owner: owner, Kind: "test-kind"}
+
+		// the first provider starts the watch
+		Expect(registry.SetProviderWatches("test-provider-1", []ProviderWatch{watch})).To(Succeed())
+		Eventually(func() bool {
+			return spyController.watched(&watchable{
+				source: source,
+				owner:  owner,
+			})
+		}, timeout).Should(BeTrue())
+		Expect(registry.ActiveWatches()).To(Equal([]ActiveProviderWatch{
+			{GroupVersionKind: providerGVK("test-kind").String(), Owner: "*v1alpha1.DBaaSInventory", Providers: []string{"test-provider-1"}},
+		}))
+
+		// other providers of the same kind, and repeated reconciles, share the watch
+		Expect(registry.SetProviderWatches("test-provider-2", []ProviderWatch{watch})).To(Succeed())
+		Expect(registry.SetProviderWatches("test-provider-1", []ProviderWatch{watch})).To(Succeed())
+		Consistently(func() int {
+			return spyController.watchCount(&watchable{
+				source: source,
+				owner:  owner,
+			})
+		}).Should(Equal(1))
+		Expect(registry.ActiveWatches()).To(Equal([]ActiveProviderWatch{
+			{GroupVersionKind: providerGVK("test-kind").String(), Owner: "*v1alpha1.DBaaSInventory", Providers: []string{"test-provider-1", "test-provider-2"}},
+		}))
+
+		// the watch is stopped once no provider references the kind
+		Expect(registry.SetProviderWatches("test-provider-1", nil)).To(Succeed())
+		Expect(registry.ActiveWatches()).To(HaveLen(1))
+		updatedWatch := watch
+		updatedWatch.Kind = "updated-test-kind"
+		Expect(registry.SetProviderWatches("test-provider-2", []ProviderWatch{updatedWatch})).To(Succeed())
+		Expect(registry.ActiveWatches()).To(Equal([]ActiveProviderWatch{
+			{GroupVersionKind: providerGVK("updated-test-kind").String(), Owner: "*v1alpha1.DBaaSInventory", Providers: []string{"test-provider-2"}},
+		}))
+	})
+
+	It("should not watch a kind for different owners", func() {
+		spyController := newSpyController(nil)
+		Expect(registry.SetProviderWatches("test-provider-1", []ProviderWatch{
+			{Controller: spyController, Owner: &v1alpha1.DBaaSInventory{}, Kind: "test-kind"},
+		})).To(Succeed())
+		Expect(registry.SetProviderWatches("test-provider-2", []ProviderWatch{
+			{Controller: spyController, Owner: &v1alpha1.DBaaSConnection{}, Kind: "test-kind"},
+		})).NotTo(Succeed())
+	})
+})
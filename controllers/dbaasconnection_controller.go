@@ -26,20 +26,29 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
 )
 
 // DBaaSConnectionReconciler reconciles a DBaaSConnection object
 type DBaaSConnectionReconciler struct {
 	*DBaaSReconciler
+	// reads the developer topology deployments from the API server, the deployments are only cached in the install
+	// namespace
+	deploymentClient client.Client
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -112,8 +121,25 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSConnectionReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	deploymentClient, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+		CacheReader: mgr.GetAPIReader(),
+		Client:      r.Client,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.deploymentClient = deploymentClient
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBaaSConnection{}).
+		// namespace label changes re-evaluate the connection namespace selectors
+		Watches(
+			&source.Kind{Type: &v1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceObjectsMapFunc(func() client.ObjectList {
+				return &v1alpha1.DBaaSConnectionList{}
+			})),
+			builder.WithPredicates(namespaceLabelsChanged),
+		).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
@@ -127,11 +153,19 @@ func (r *DBaaSConnectionReconciler) reconcileDevTopologyResource(connection *v1a
 			Namespace: connection.Namespace,
 		},
 	}
-	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, r.deploymentMutateFn(connection, deployment))
+	platform, err := r.getDBaaSPlatform(ctx)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.deploymentClient, deployment, r.deploymentMutateFn(connection, deployment, platform))
 	return result, err
 }
 
-func (r *DBaaSConnectionReconciler) deploymentMutateFn(connection *v1alpha1.DBaaSConnection, deployment *appv1.Deployment) controllerutil.MutateFn {
+func (r *DBaaSConnectionReconciler) deploymentMutateFn(connection *v1alpha1.DBaaSConnection, deployment *appv1.Deployment, platform *v1alpha1.DBaaSPlatform) controllerutil.MutateFn {
+	var imageOverride string
+	if platform != nil && platform.Spec.Images != nil {
+		imageOverride = platform.Spec.Images.ConnectionBinding
+	}
 	return func() error {
 		deployment.ObjectMeta.Labels = map[string]string{
 			"managed-by":      "dbaas-operator",
@@ -156,8 +190,8 @@ func (r *DBaaSConnectionReconciler) deploymentMutateFn(connection *v1alpha1.DBaa
 					Containers: []v1.Container{
 						{
 							Name:            "bind-deploy",
-							Image:           "quay.io/ecosystem-appeng/busybox",
-							ImagePullPolicy: v1.PullIfNotPresent,
+							Image:           reconcilers.GetImage(imageOverride, reconcilers.CONNECTION_BINDING_IMG_ENV, reconcilers.CONNECTION_BINDING_IMG),
+							ImagePullPolicy: reconcilers.GetImagePullPolicy(platform, v1.PullIfNotPresent),
 							Command:         []string{"sh", "-c", "echo The app is running! && sleep 3600"},
 						},
 					},
@@ -18,9 +18,11 @@ package controllers
 
 import (
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 )
@@ -514,6 +516,81 @@ var _ = Describe("DBaaSConnection controller - valid dev namespaces", func() {
 			BeforeEach(assertInventoryCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
 			AfterEach(assertResourceDeletion(createdDBaaSInventory))
 		})
+
+		Context("after creating DBaaSInventory w/ dev namespace selector set", func() {
+			selectorNS := v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "other-selector",
+					Labels: map[string]string{"dbaas-dev": "true"},
+				},
+			}
+			inventoryRefName := "test-inventory-ref-selector"
+			createdDBaaSInventory := &v1alpha1.DBaaSInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      inventoryRefName,
+					Namespace: testNamespace,
+				},
+				Spec: v1alpha1.DBaaSOperatorInventorySpec{
+					ProviderRef: v1alpha1.NamespacedName{
+						Name: testProviderName,
+					},
+					DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+						CredentialsRef: &v1alpha1.NamespacedName{
+							Name:      testSecret.Name,
+							Namespace: testNamespace,
+						},
+					},
+					ConnectionNamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"dbaas-dev": "true"},
+					},
+				},
+			}
+			lastTransitionTime := getLastTransitionTimeForTest()
+			providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               "SpecSynced",
+						Status:             metav1.ConditionTrue,
+						Reason:             "SyncOK",
+						LastTransitionTime: metav1.Time{Time: lastTransitionTime},
+					},
+				},
+			}
+
+			Context("after creating DBaaSConnection in a namespace matching the selector", func() {
+				DBaaSConnectionSpec := &v1alpha1.DBaaSConnectionSpec{
+					InventoryRef: v1alpha1.NamespacedName{
+						Name:      inventoryRefName,
+						Namespace: testNamespace,
+					},
+					InstanceID: "test-instanceID",
+				}
+				createdDBaaSConnection := &v1alpha1.DBaaSConnection{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-connection-selector",
+						Namespace: selectorNS.Name,
+					},
+					Spec: *DBaaSConnectionSpec,
+				}
+				BeforeEach(assertResourceCreation(createdDBaaSConnection))
+				AfterEach(assertResourceDeletion(createdDBaaSConnection))
+
+				It("should create a provider connection", assertProviderResourceCreated(createdDBaaSConnection, testConnectionKind, DBaaSConnectionSpec))
+				Context("when removing the namespace label", func() {
+					BeforeEach(func() {
+						ns := &v1.Namespace{}
+						Expect(dRec.Get(ctx, client.ObjectKeyFromObject(&selectorNS), ns)).Should(Succeed())
+						ns.Labels = nil
+						Expect(dRec.Update(ctx, ns)).Should(Succeed())
+					})
+					It("should move the DBaaSConnection to an invalid namespace status", assertDBaaSResourceStatusUpdated(createdDBaaSConnection, metav1.ConditionFalse, v1alpha1.DBaaSInvalidNamespace))
+				})
+			})
+
+			BeforeEach(assertResourceCreationIfNotExists(&selectorNS))
+			BeforeEach(assertInventoryCreationWithProviderStatus(createdDBaaSInventory, metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+			AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		})
 	})
 
 })
@@ -18,24 +18,39 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 )
 
+// instanceExpiryWarningPeriod is how long before an instance expires that its expiring condition is set, with a warning event
+const instanceExpiryWarningPeriod = time.Hour
+
 // DBaaSInstanceReconciler reconciles a DBaaSInstance object
 type DBaaSInstanceReconciler struct {
 	*DBaaSReconciler
+	recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -56,7 +71,45 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
-	if inventory, validNS, err := r.checkInventory(instance.Spec.InventoryRef, &instance, func(reason string, message string) {
+	// instances expire regardless of the state of their inventory
+	expiresAt, err := r.getInstanceExpiry(ctx, &instance)
+	if err != nil {
+		logger.Error(err, "Error determining the DBaaS Instance expiry")
+		return ctrl.Result{}, err
+	}
+	if expiresAt != nil && !time.Now().Before(expiresAt.Time) {
+		return ctrl.Result{}, r.deleteExpiredInstance(ctx, &instance, expiresAt)
+	}
+	if err := r.setExpiringCondition(ctx, &instance, expiresAt); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Instance modified, retry reconciling")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Instance status")
+		return ctrl.Result{}, err
+	}
+
+	result, err := r.reconcileInstance(ctx, &instance)
+	if err != nil || result.Requeue || expiresAt == nil {
+		return result, err
+	}
+
+	// requeue for the expiry warning or the expiry itself, whichever comes first
+	requeueAfter := time.Until(expiresAt.Time)
+	if requeueAfter > instanceExpiryWarningPeriod {
+		requeueAfter -= instanceExpiryWarningPeriod
+	}
+	if result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter {
+		result.RequeueAfter = requeueAfter
+	}
+	return result, nil
+}
+
+// reconcileInstance reconciles the provider instance of a DBaaSInstance with a valid inventory
+func (r *DBaaSInstanceReconciler) reconcileInstance(ctx context.Context, instance *v1alpha1.DBaaSInstance) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if inventory, validNS, err := r.checkInventory(instance.Spec.InventoryRef, instance, func(reason string, message string) {
 		cond := metav1.Condition{
 			Type:    v1alpha1.DBaaSInstanceReadyType,
 			Status:  metav1.ConditionFalse,
@@ -69,20 +122,37 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	} else if !validNS {
 		return ctrl.Result{}, nil
 	} else {
+		// a missing provider is reported when reconciling the provider resource
+		provider, err := r.getDBaaSProvider(inventory.Spec.ProviderRef.Name, ctx)
+		if err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Error fetching DBaaS Provider for reconcile")
+			return ctrl.Result{}, err
+		}
+
+		// instances provisioned before the provider stopped supporting provisioning keep being reconciled
+		if provider != nil && !provider.Spec.GetCapabilities().SupportsProvisioning() {
+			if provisioned, err := r.isProvisioned(ctx, instance, provider.Spec.InstanceKind); err != nil {
+				logger.Error(err, "Error fetching the provider instance", "Kind", provider.Spec.InstanceKind)
+				return ctrl.Result{}, err
+			} else if !provisioned {
+				return r.rejectProvisioning(ctx, instance)
+			}
+		}
+
 		return r.reconcileProviderResource(inventory.Spec.ProviderRef.Name,
-			&instance,
+			instance,
 			func(provider *v1alpha1.DBaaSProvider) string {
 				return provider.Spec.InstanceKind
 			},
 			func() interface{} {
-				return instance.Spec.DeepCopy()
+				return instance.Spec.DBaaSInstanceSpec.DeepCopy()
 			},
 			func() interface{} {
 				return &v1alpha1.DBaaSProviderInstance{}
 			},
 			func(i interface{}) metav1.Condition {
 				providerInstance := i.(*v1alpha1.DBaaSProviderInstance)
-				return mergeInstanceStatus(&instance, providerInstance)
+				return mergeInstanceStatus(instance, providerInstance)
 			},
 			func() *[]metav1.Condition {
 				return &instance.Status.Conditions
@@ -94,10 +164,184 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 }
 
+// getInstanceExpiry returns the time at which the instance expires, or nil if it doesn't expire.
+// An explicit expiresAt takes precedence over ttl, which takes precedence over the tenant's trial default.
+// The inventory is only read for the trial default, a missing inventory is reported by checkInventory.
+func (r *DBaaSInstanceReconciler) getInstanceExpiry(ctx context.Context, instance *v1alpha1.DBaaSInstance) (*metav1.Time, error) {
+	if instance.Spec.ExpiresAt != nil {
+		return instance.Spec.ExpiresAt, nil
+	}
+	ttl := instance.Spec.TTL
+	if ttl == nil {
+		inventory, err := r.getInstanceInventory(ctx, instance)
+		if err != nil || inventory == nil {
+			return nil, err
+		}
+		if ttl, err = r.getTrialInstanceTTL(ctx, inventory); err != nil || ttl == nil {
+			return nil, err
+		}
+	}
+	expiresAt := metav1.NewTime(instance.CreationTimestamp.Add(ttl.Duration))
+	return &expiresAt, nil
+}
+
+// returns the inventory referenced by the instance, or nil if it doesn't exist
+func (r *DBaaSInstanceReconciler) getInstanceInventory(ctx context.Context, instance *v1alpha1.DBaaSInstance) (*v1alpha1.DBaaSInventory, error) {
+	inventory := &v1alpha1.DBaaSInventory{}
+	inventoryRef := instance.Spec.InventoryRef
+	if err := r.Get(ctx, types.NamespacedName{Namespace: v1alpha1.InventoryRefNamespace(inventoryRef, instance.Namespace), Name: inventoryRef.Name}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// getTrialInstanceTTL returns the trial ttl of the tenant managing the inventory, if the inventory's provider offers free trials
+func (r *DBaaSInstanceReconciler) getTrialInstanceTTL(ctx context.Context, inventory *v1alpha1.DBaaSInventory) (*metav1.Duration, error) {
+	tenantList, err := r.tenantListByInventoryNS(ctx, inventory.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenant := range tenantList.Items {
+		if tenant.Spec.TrialInstanceTTL == nil {
+			continue
+		}
+		provider, err := r.getDBaaSProvider(inventory.Spec.ProviderRef.Name, ctx)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				// the missing provider is reported in the instance status by reconcileProviderResource
+				return nil, nil
+			}
+			return nil, err
+		}
+		if !provider.Spec.AllowsFreeTrial {
+			return nil, nil
+		}
+		return tenant.Spec.TrialInstanceTTL, nil
+	}
+	return nil, nil
+}
+
+// setExpiringCondition sets the expiring condition of an instance expiring within the warning period, emitting a
+// warning event when the condition changes, or removes the condition otherwise
+func (r *DBaaSInstanceReconciler) setExpiringCondition(ctx context.Context, instance *v1alpha1.DBaaSInstance, expiresAt *metav1.Time) error {
+	previous := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType)
+	if expiresAt == nil || time.Until(expiresAt.Time) > instanceExpiryWarningPeriod {
+		if previous == nil {
+			return nil
+		}
+		apimeta.RemoveStatusCondition(&instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType)
+		return r.Client.Status().Update(ctx, instance)
+	}
+
+	cond := metav1.Condition{
+		Type:    v1alpha1.DBaaSInstanceExpiringType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.DBaaSInstanceExpiring,
+		Message: fmt.Sprintf("DBaaS Instance expires at %s", expiresAt.UTC().Format(time.RFC3339)),
+	}
+	if previous != nil && previous.Status == cond.Status && previous.Message == cond.Message {
+		return nil
+	}
+	apimeta.SetStatusCondition(&instance.Status.Conditions, cond)
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		return err
+	}
+	r.recorder.Event(instance, corev1.EventTypeWarning, cond.Reason, cond.Message)
+	return nil
+}
+
+// deleteExpiredInstance deletes an expired instance, orphaning the provider instance if requested by the deletion policy
+func (r *DBaaSInstanceReconciler) deleteExpiredInstance(ctx context.Context, instance *v1alpha1.DBaaSInstance, expiresAt *metav1.Time) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	if instance.Spec.DeletionPolicy == v1alpha1.DeletionPolicyOrphan {
+		propagationPolicy = metav1.DeletePropagationOrphan
+	} else if capabilities, err := r.getInstanceCapabilities(ctx, instance); err != nil {
+		logger.Error(err, "Error fetching the DBaaS Provider capabilities")
+		return err
+	} else if !capabilities.SupportsDeletion() {
+		// the provider can't delete the instance, so its provider resource is kept
+		propagationPolicy = metav1.DeletePropagationOrphan
+		r.recorder.Event(instance, corev1.EventTypeWarning, v1alpha1.DBaaSInstanceExpired,
+			"The provider does not support deleting instances, the provider instance is orphaned")
+	}
+	logger.Info("DBaaS Instance expired, deleting", "DBaaS Instance", instance.Name, "expiresAt", expiresAt, "propagationPolicy", propagationPolicy)
+	r.recorder.Event(instance, corev1.EventTypeNormal, v1alpha1.DBaaSInstanceExpired,
+		fmt.Sprintf("DBaaS Instance expired at %s and is being deleted", expiresAt.UTC().Format(time.RFC3339)))
+	if err := r.Delete(ctx, instance, client.PropagationPolicy(propagationPolicy)); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Error deleting expired DBaaS Instance", "DBaaS Instance", instance.Name)
+		return err
+	}
+	return nil
+}
+
+// returns the capabilities of the provider of the instance inventory, the default capabilities if either is missing
+func (r *DBaaSInstanceReconciler) getInstanceCapabilities(ctx context.Context, instance *v1alpha1.DBaaSInstance) (v1alpha1.DBaaSProviderCapabilities, error) {
+	capabilities := (&v1alpha1.DBaaSProviderSpec{}).GetCapabilities()
+	inventory, err := r.getInstanceInventory(ctx, instance)
+	if err != nil || inventory == nil {
+		return capabilities, err
+	}
+	provider, err := r.getDBaaSProvider(inventory.Spec.ProviderRef.Name, ctx)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return capabilities, nil
+		}
+		return capabilities, err
+	}
+	return provider.Spec.GetCapabilities(), nil
+}
+
+// checks if the provider instance of a DBaaSInstance exists
+func (r *DBaaSInstanceReconciler) isProvisioned(ctx context.Context, instance *v1alpha1.DBaaSInstance, instanceKind string) (bool, error) {
+	providerObject := r.createProviderObject(instance, instanceKind)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// reports that the provider doesn't support provisioning the instance, no requeue until the instance changes
+func (r *DBaaSInstanceReconciler) rejectProvisioning(ctx context.Context, instance *v1alpha1.DBaaSInstance) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	cond := metav1.Condition{
+		Type:    v1alpha1.DBaaSInstanceReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ProvisioningNotSupported,
+		Message: v1alpha1.MsgProvisioningNotSupported,
+	}
+	apimeta.SetStatusCondition(&instance.Status.Conditions, cond)
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Instance modified, retry reconciling")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Instance status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSInstanceReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	r.recorder = mgr.GetEventRecorderFor("dbaasinstance-controller")
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBaaSInstance{}).
+		// namespace label changes re-evaluate the connection namespace selectors
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceObjectsMapFunc(func() client.ObjectList {
+				return &v1alpha1.DBaaSInstanceList{}
+			})),
+			builder.WithPredicates(namespaceLabelsChanged),
+		).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
@@ -106,7 +350,12 @@ func (r *DBaaSInstanceReconciler) SetupWithManager(mgr ctrl.Manager) (controller
 
 // mergeInstanceStatus: merge the status from DBaaSProviderInstance into the current DBaaSInstance status
 func mergeInstanceStatus(instance *v1alpha1.DBaaSInstance, providerInst *v1alpha1.DBaaSProviderInstance) metav1.Condition {
+	// the expiring condition is set by the operator, not the provider
+	expiring := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType)
 	providerInst.Status.DeepCopyInto(&instance.Status)
+	if expiring != nil {
+		apimeta.SetStatusCondition(&instance.Status.Conditions, *expiring)
+	}
 	// Update instance status condition (type: DBaaSInstanceReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerInst.Status.Conditions, v1alpha1.DBaaSInstanceProviderSyncType)
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
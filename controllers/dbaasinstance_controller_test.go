@@ -17,10 +17,20 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"testing"
+	"time"
+
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 )
@@ -47,7 +57,9 @@ var _ = Describe("DBaaSInstance controller with errors", func() {
 				Name:      instanceName,
 				Namespace: testNamespace,
 			},
-			Spec: *DBaaSInstanceSpec,
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				DBaaSInstanceSpec: *DBaaSInstanceSpec,
+			},
 		}
 
 		BeforeEach(assertResourceCreation(createdDBaaSInstance))
@@ -92,7 +104,9 @@ var _ = Describe("DBaaSInstance controller with errors", func() {
 				Name:      instanceName,
 				Namespace: testNamespace,
 			},
-			Spec: *DBaaSInstanceSpec,
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				DBaaSInstanceSpec: *DBaaSInstanceSpec,
+			},
 		}
 		lastTransitionTime := getLastTransitionTimeForTest()
 		providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
@@ -167,7 +181,9 @@ var _ = Describe("DBaaSInstance controller with errors", func() {
 				Name:      instanceName,
 				Namespace: otherNS.Name,
 			},
-			Spec: *DBaaSInstanceSpec,
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				DBaaSInstanceSpec: *DBaaSInstanceSpec,
+			},
 		}
 		lastTransitionTime := getLastTransitionTimeForTest()
 		providerInventoryStatus := &v1alpha1.DBaaSInventoryStatus{
@@ -267,7 +283,9 @@ var _ = Describe("DBaaSInstance controller - nominal", func() {
 						Name:      instanceName,
 						Namespace: testNamespace,
 					},
-					Spec: *DBaaSInstanceSpec,
+					Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+						DBaaSInstanceSpec: *DBaaSInstanceSpec,
+					},
 				}
 				BeforeEach(assertResourceCreation(createdDBaaSInstance))
 				AfterEach(assertResourceDeletion(createdDBaaSInstance))
@@ -309,6 +327,32 @@ var _ = Describe("DBaaSInstance controller - nominal", func() {
 					It("should update provider instance spec", assertProviderResourceSpecUpdated(createdDBaaSInstance, testInstanceKind, DBaaSInstanceSpec))
 				})
 			})
+			Context("after creating an expired DBaaSInstance", func() {
+				expiredDBaaSInstance := &v1alpha1.DBaaSInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-expired-instance",
+						Namespace: testNamespace,
+					},
+					Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+						ExpiresAt: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+						DBaaSInstanceSpec: v1alpha1.DBaaSInstanceSpec{
+							InventoryRef: v1alpha1.NamespacedName{
+								Name:      inventoryRefName,
+								Namespace: testNamespace,
+							},
+							Name: "test-expired-instance",
+						},
+					},
+				}
+				BeforeEach(assertResourceCreation(expiredDBaaSInstance))
+
+				It("should delete the DBaaSInstance", func() {
+					Eventually(func() bool {
+						err := dRec.Get(ctx, client.ObjectKeyFromObject(expiredDBaaSInstance), &v1alpha1.DBaaSInstance{})
+						return errors.IsNotFound(err)
+					}, timeout).Should(BeTrue())
+				})
+			})
 		})
 	})
 })
@@ -384,7 +428,9 @@ var _ = Describe("DBaaSInstance controller - valid dev namespaces", func() {
 						Name:      instanceName,
 						Namespace: otherNS.Name,
 					},
-					Spec: *DBaaSInstanceSpec,
+					Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+						DBaaSInstanceSpec: *DBaaSInstanceSpec,
+					},
 				}
 				BeforeEach(assertResourceCreation(createdDBaaSInstance))
 				AfterEach(assertResourceDeletion(createdDBaaSInstance))
@@ -497,7 +543,9 @@ var _ = Describe("DBaaSInstance controller - valid dev namespaces", func() {
 						Name:      instanceName,
 						Namespace: otherNS.Name,
 					},
-					Spec: *DBaaSInstanceSpec,
+					Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+						DBaaSInstanceSpec: *DBaaSInstanceSpec,
+					},
 				}
 				BeforeEach(assertResourceCreation(createdDBaaSInstance))
 				AfterEach(assertResourceDeletion(createdDBaaSInstance))
@@ -546,3 +594,147 @@ var _ = Describe("DBaaSInstance controller - valid dev namespaces", func() {
 		})
 	})
 })
+
+var _ = Describe("DBaaSInstance controller - expiry", func() {
+	Context("after creating DBaaSInstances with a ttl and without inventory", func() {
+		inventoryRef := v1alpha1.NamespacedName{
+			Name:      "test-inventory-no-exist-ref",
+			Namespace: testNamespace,
+		}
+		expiredInstance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-ttl-expired-instance",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				TTL: &metav1.Duration{Duration: time.Second},
+				DBaaSInstanceSpec: v1alpha1.DBaaSInstanceSpec{
+					InventoryRef: inventoryRef,
+					Name:         "test-ttl-expired-instance",
+				},
+			},
+		}
+		expiringInstance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-ttl-expiring-instance",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				TTL: &metav1.Duration{Duration: 30 * time.Minute},
+				DBaaSInstanceSpec: v1alpha1.DBaaSInstanceSpec{
+					InventoryRef: inventoryRef,
+					Name:         "test-ttl-expiring-instance",
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(expiredInstance))
+		BeforeEach(assertResourceCreation(expiringInstance))
+		AfterEach(assertResourceDeletion(expiringInstance))
+
+		It("should delete the expired DBaaSInstance", func() {
+			Eventually(func() bool {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(expiredInstance), &v1alpha1.DBaaSInstance{})
+				return errors.IsNotFound(err)
+			}, timeout).Should(BeTrue())
+		})
+		It("should set the expiring condition of the DBaaSInstance expiring soon", func() {
+			Eventually(func() string {
+				instance := &v1alpha1.DBaaSInstance{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(expiringInstance), instance); err != nil {
+					return ""
+				}
+				if cond := apimeta.FindStatusCondition(instance.Status.Conditions, v1alpha1.DBaaSInstanceExpiringType); cond != nil &&
+					cond.Status == metav1.ConditionTrue {
+					return cond.Reason
+				}
+				return ""
+			}, timeout).Should(Equal(v1alpha1.DBaaSInstanceExpiring))
+		})
+	})
+
+	Context("after creating a DBaaSInstance with the trial ttl of the inventory tenant", func() {
+		trialNS := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "trial-ttl-ns",
+			},
+		}
+		trialProvider := mongoProvider.DeepCopy()
+		trialProvider.Name = "trial-provider"
+		trialProvider.Spec.Provider.Name = "trial-provider"
+		trialProvider.Spec.AllowsFreeTrial = true
+		trialTenant := getDefaultTenant(trialNS.Name)
+		trialTenant.Name = "trial-tenant"
+		trialTenant.Spec.TrialInstanceTTL = &metav1.Duration{Duration: time.Second}
+		trialInventory := &v1alpha1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "trial-inventory",
+				Namespace: trialNS.Name,
+			},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1alpha1.NamespacedName{
+					Name: trialProvider.Name,
+				},
+			},
+		}
+		trialInstance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "trial-instance",
+				Namespace: trialNS.Name,
+			},
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				DBaaSInstanceSpec: v1alpha1.DBaaSInstanceSpec{
+					InventoryRef: v1alpha1.NamespacedName{
+						Name:      trialInventory.Name,
+						Namespace: trialNS.Name,
+					},
+					Name: "trial-instance",
+				},
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(trialNS))
+		BeforeEach(assertResourceCreationIfNotExists(trialProvider))
+		BeforeEach(assertResourceCreationIfNotExists(&trialTenant))
+		BeforeEach(assertResourceCreationIfNotExists(trialInventory))
+		BeforeEach(assertResourceCreation(trialInstance))
+		AfterEach(assertResourceDeletion(trialInventory))
+		AfterEach(assertResourceDeletion(&trialTenant))
+		AfterEach(assertResourceDeletion(trialProvider))
+
+		It("should delete the DBaaSInstance once the trial ttl elapsed", func() {
+			Eventually(func() bool {
+				err := dRec.Get(ctx, client.ObjectKeyFromObject(trialInstance), &v1alpha1.DBaaSInstance{})
+				return errors.IsNotFound(err)
+			}, timeout).Should(BeTrue())
+		})
+	})
+})
+
+func TestGetInstanceInventory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+	inventory := &v1alpha1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "test-ns"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(inventory).Build()
+	r := &DBaaSInstanceReconciler{DBaaSReconciler: &DBaaSReconciler{Client: c, Scheme: scheme}}
+	instance := &v1alpha1.DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "test-ns"},
+		Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+			DBaaSInstanceSpec: v1alpha1.DBaaSInstanceSpec{
+				InventoryRef: v1alpha1.NamespacedName{Name: "inventory"},
+			},
+		},
+	}
+
+	// the inventory ref without namespace references the inventory of the instance namespace
+	instanceInventory, err := r.getInstanceInventory(context.Background(), instance)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(instanceInventory).NotTo(BeNil())
+	Expect(instanceInventory.Name).To(Equal("inventory"))
+
+	instance.Spec.InventoryRef.Namespace = "other-ns"
+	instanceInventory, err = r.getInstanceInventory(context.Background(), instance)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(instanceInventory).To(BeNil())
+}
@@ -118,6 +118,28 @@ var _ = Describe("DBaaSInventory controller - nominal", func() {
 			AfterEach(assertResourceDeletion(createdDBaaSInventory))
 
 			It("should create a provider inventory", assertProviderResourceCreated(createdDBaaSInventory, testInventoryKind, DBaaSInventorySpec))
+			It("should generate an access report", func() {
+				report := &v1alpha1.DBaaSAccessReport{}
+				Eventually(func() []string {
+					err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), report)
+					if err != nil || report.Status.GeneratedAt == nil {
+						return nil
+					}
+					return report.Status.Tenants
+				}, timeout).Should(ContainElement(defaultTenant.Name))
+				Expect(report.OwnerReferences).To(HaveLen(1))
+				Expect(report.OwnerReferences[0].Name).To(Equal(createdDBaaSInventory.Name))
+
+				By("overwriting the status written by another writer")
+				report.Status.Tenants = []string{"tampered-tenant"}
+				Expect(dRec.Status().Update(ctx, report)).Should(Succeed())
+				Eventually(func() []string {
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), report); err != nil {
+						return nil
+					}
+					return report.Status.Tenants
+				}, timeout).Should(And(ContainElement(defaultTenant.Name), Not(ContainElement("tampered-tenant"))))
+			})
 
 			Context("when updating provider inventory status", func() {
 				lastTransitionTime := getLastTransitionTimeForTest()
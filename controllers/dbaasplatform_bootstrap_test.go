@@ -0,0 +1,106 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// the operator is started in its own environment, serving the DBaaSPlatform webhook with no DBaaSPlatform created yet
+var _ = Describe("DBaaSPlatform bootstrap", func() {
+	It("should create the DBaaSPlatform once the webhook server of the operator is up", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+
+		env := &envtest.Environment{
+			CRDDirectoryPaths:     []string{filepath.Join("..", "config", "crd", "bases")},
+			ErrorIfCRDPathMissing: true,
+			WebhookInstallOptions: envtest.WebhookInstallOptions{
+				Paths: []string{filepath.Join("..", "config", "webhook")},
+			},
+		}
+		cfg, err := env.Start()
+		Expect(err).NotTo(HaveOccurred())
+		defer func() {
+			Expect(env.Stop()).Should(Succeed())
+		}()
+
+		webhookInstallOptions := &env.WebhookInstallOptions
+		mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+			Scheme:             scheme,
+			Host:               webhookInstallOptions.LocalServingHost,
+			Port:               webhookInstallOptions.LocalServingPort,
+			CertDir:            webhookInstallOptions.LocalServingCertDir,
+			MetricsBindAddress: "0",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect((&v1alpha1.DBaaSPlatform{}).SetupWebhookWithManager(mgr)).Should(Succeed())
+		Expect((&DBaaSPlatformReconciler{
+			DBaaSReconciler: &DBaaSReconciler{
+				Client:           mgr.GetClient(),
+				Scheme:           mgr.GetScheme(),
+				InstallNamespace: testNamespace,
+			},
+			Log: ctrl.Log.WithName("controllers").WithName("DBaaSPlatform"),
+		}).SetupWithManager(mgr)).Should(Succeed())
+
+		mgrCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(mgr.Start(mgrCtx)).Should(Succeed())
+		}()
+
+		c, err := client.New(cfg, client.Options{Scheme: scheme})
+		Expect(err).NotTo(HaveOccurred())
+		platform := &v1alpha1.DBaaSPlatform{}
+		Eventually(func() error {
+			return c.Get(mgrCtx, client.ObjectKey{Namespace: testNamespace, Name: "dbaas-platform"}, platform)
+		}, timeout).Should(Succeed())
+
+		By("checking the webhook validates the DBaaSPlatforms")
+		invalidPlatform := &v1alpha1.DBaaSPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-platform", Namespace: testNamespace},
+			Spec: v1alpha1.DBaaSPlatformSpec{
+				Providers: []v1alpha1.DBaaSPlatformProvider{
+					{
+						Name: v1alpha1.MongoDBAtlasInstallation,
+						Env:  []corev1.EnvVar{{Name: v1alpha1.SyncPeriodEnvName, Value: "60"}},
+					},
+				},
+			},
+		}
+		err = c.Create(mgrCtx, invalidPlatform)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("admission webhook \"vdbaasplatform.kb.io\" denied the request"))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,12 +33,29 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/go-logr/logr"
-
+	consolev1 "github.com/openshift/api/console/v1"
+	consolev1alpha1 "github.com/openshift/api/console/v1alpha1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 /*
@@ -45,26 +63,37 @@ Platform resources to be created or updated explicitly by the DBaaS operator:
 	Crunchy Bridge operator: CatalogSource, Subscription, OperatorGroup
 	MongoDB Atlas operator: CatalogSource, Subscription, OperatorGroup
 	Service Binding operator: Subscription
-	DBaaS Dynamic Plugin: Service, Deployment, ConsolePlugin, Console (updated)
-	Console Telemetry Plugin: Service, Deployment, ConsolePlugin, Console (updated)
+	DBaaS Dynamic Plugin: Service, Deployment, PodDisruptionBudget, ConsolePlugin, Console (updated)
+	Console Telemetry Plugin: Service, Deployment, PodDisruptionBudget, ConsolePlugin, Console (updated)
 
 Platform resources to be removed by the DBaaS operator by setting owner reference:
 	Crunchy Bridge operator: Subscription, CSV
 	MongoDB Atlas operator: Subscription, CSV
 	Service Binding operator: Subscription, CSV
-	DBaaS Dynamic Plugin: Service, Deployment
-	Console Telemetry Plugin: Service, Deployment
+	DBaaS Dynamic Plugin: Service, Deployment, PodDisruptionBudget
+	Console Telemetry Plugin: Service, Deployment, PodDisruptionBudget
 
 Platform resources NOT to be removed or updated by the DBaaS operator:
 	Crunchy Bridge operator: CatalogSource (in different namespace), OperatorGroup (in different namespace)
 	MongoDB Atlas operator: CatalogSource (in different namespace), OperatorGroup (in different namespace)
 	DBaaS Dynamic Plugin: ConsolePlugin (cluster-scoped), Console (cluster-scoped)
 	Console Telemetry Plugin: ConsolePlugin (cluster-scoped), Console (cluster-scoped)
+	Provider operators installed by admins: Subscription, CSV, unless the subscription is adopted
+	Existing OperatorGroups of the provider operators namespace
+
+Once installed, the platform resources are watched: the health of the platforms is checked on their changes, and
+reported by the Degraded condition of the DBaaSPlatform, while their reconciliation repairs them.
+
+Uninstalling all the platforms, or a single provider platform, also removes the provider operator CatalogSource, and the
+OperatorGroup once all the providers are uninstalled, if the DBaaS operator created it. Purging first deletes the DBaaS
+connections, instances and inventories, then the provider CRDs with the provider operators.
 */
 
 const (
 	RequeueDelaySuccess = 10 * time.Second
 	RequeueDelayError   = 5 * time.Second
+
+	platformBootstrapInterval = 5 * time.Second
 )
 
 // DBaaSPlatformReconciler reconciles a DBaaSPlatform object
@@ -74,6 +103,11 @@ type DBaaSPlatformReconciler struct {
 	installComplete     bool
 	operatorNameVersion string
 	OcpVersion          string
+	// The APIs of the cluster the platforms depend on, the platforms with missing APIs are skipped
+	Capabilities reconcilers.ClusterCapabilities
+	recorder     record.EventRecorder
+	// reads the provider operator deployments outside of the install namespace, which are not cached
+	apiReader k8sclient.Reader
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -81,11 +115,16 @@ type DBaaSPlatformReconciler struct {
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=catalogsources;operatorgroups,verbs=get;list;create;update;watch
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=subscriptions,verbs=get;list;create;update;watch;delete
-//+kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions,verbs=get;update;delete
+//+kubebuilder:rbac:groups=operators.coreos.com,resources=installplans,verbs=get;list;update;watch
+//+kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions,verbs=get;list;watch;update;delete
 //+kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;statefulsets,verbs=get;list;create;update;watch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;create;update;watch;delete
-//+kubebuilder:rbac:groups=console.openshift.io,resources=consoleplugins;consolequickstarts,verbs=get;list;create;update;watch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;create;update;watch;delete
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=delete
+//+kubebuilder:rbac:groups=console.openshift.io,resources=consoleplugins;consolequickstarts,verbs=get;list;create;update;watch;delete
 //+kubebuilder:rbac:groups=operator.openshift.io,resources=consoles,verbs=get;list;update;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -119,17 +158,75 @@ func (r *DBaaSPlatformReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	nextStatus := cr.Status.DeepCopy()
 
+	skipped := map[dbaasv1alpha1.PlatformsName]bool{}
+	nextStatus.Skipped = nil
 	for platform, platformConfig := range platforms {
+		if reason := r.getSkipReason(cr, platform, platformConfig); reason != "" {
+			skipped[platform] = true
+			nextStatus.Skipped = append(nextStatus.Skipped, dbaasv1alpha1.DBaaSPlatformSkipped{Name: platform, Reason: reason})
+		}
+	}
+	sort.Slice(nextStatus.Skipped, func(i, j int) bool {
+		return nextStatus.Skipped[i].Name < nextStatus.Skipped[j].Name
+	})
+
+	// the DBaaS objects are purged first, while the provider operators are still running
+	if cr.DeletionTimestamp == nil && cr.Spec.IsPurged() {
+		purged, err := r.purgeDBaaSObjects(ctx, nextStatus)
+		if err != nil {
+			logger.Error(err, "Error purging the DBaaS objects")
+			nextStatus.LastMessage = err.Error()
+			return ctrl.Result{}, err
+		}
+		if !purged {
+			logger.Info("DBaaS objects purge in progress", "phase", nextStatus.Uninstall.Phase, "remaining", nextStatus.Uninstall.Remaining)
+			return r.updateStatus(cr, nextStatus, true)
+		}
+	}
+
+	// the health of the platforms is monitored once they are installed, before repairing them
+	var degraded []string
+	if cr.DeletionTimestamp == nil && cr.Spec.Uninstall == nil && apimeta.FindStatusCondition(cr.Status.Conditions, dbaasv1alpha1.DBaaSPlatformDegradedType) != nil {
+		degraded, err = r.checkHealth(ctx, cr, platforms, skipped)
+		if err != nil {
+			logger.Error(err, "Error checking the health of the DBaaS platform stack")
+			return ctrl.Result{}, err
+		}
+	}
+
+	var blocked bool
+	for platform, platformConfig := range platforms {
+		if skipped[platform] {
+			continue
+		}
 		nextStatus.PlatformName = platform
-		reconciler := r.getReconcilerForPlatform(platformConfig)
+		reconciler := r.getReconcilerForPlatform(cr, platform, platformConfig)
 		if reconciler != nil {
 			var status dbaasv1alpha1.PlatformsInstlnStatus
 			var err error
 
-			if cr.DeletionTimestamp == nil {
+			cleanup := cr.DeletionTimestamp != nil || isPlatformDisabled(cr, platform)
+			var uninstallBlocker string
+			if cr.DeletionTimestamp == nil && cr.Spec.IsUninstalled(platform) {
+				uninstallBlocker, err = r.getUninstallBlocker(ctx, cr, reconciler)
+				if err != nil {
+					nextStatus.LastMessage = err.Error()
+					return ctrl.Result{}, err
+				}
+				cleanup = uninstallBlocker == ""
+			}
+
+			if !cleanup {
 				status, err = reconciler.Reconcile(ctx, cr, nextStatus)
+				if uninstallBlocker != "" {
+					blocked = true
+					setUninstallBlocker(nextStatus, platform, uninstallBlocker)
+				}
 			} else {
 				status, err = reconciler.Cleanup(ctx, cr)
+				if platformConfig.Type == dbaasv1alpha1.TypeProvider && status == dbaasv1alpha1.ResultSuccess {
+					nextStatus.RemoveProviderStatus(platform)
+				}
 			}
 
 			if err != nil {
@@ -158,31 +255,110 @@ func (r *DBaaSPlatformReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.installComplete = true
 		logger.Info("DBaaS platform stack installation complete")
 	}
+	if cr.DeletionTimestamp == nil && cr.Spec.Uninstall == nil {
+		r.setDegradedCondition(cr, nextStatus, degraded, finished)
+	}
+	if cr.DeletionTimestamp == nil {
+		if err := r.setUninstallStatus(ctx, cr, nextStatus, finished && !blocked); err != nil {
+			nextStatus.LastMessage = err.Error()
+			return ctrl.Result{}, err
+		}
+	}
 
-	return r.updateStatus(cr, nextStatus)
+	// the crash looping pods and the inventories blocking uninstallations are not watched, they are checked again after a delay
+	return r.updateStatus(cr, nextStatus, !finished || len(degraded) > 0 || blocked)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSPlatformReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// envVar set for all operators
-	if operatorNameEnvVar, found := os.LookupEnv("OPERATOR_CONDITION_NAME"); !found {
+	// envVar set for all operators installed by OLM
+	if operatorNameEnvVar, found := os.LookupEnv("OPERATOR_CONDITION_NAME"); found {
+		r.operatorNameVersion = operatorNameEnvVar
+	} else if r.Capabilities.OLM {
 		err := fmt.Errorf("OPERATOR_CONDITION_NAME must be set")
 		return err
-	} else {
-		r.operatorNameVersion = operatorNameEnvVar
 	}
-	// Creates a new managed install CR if it is not available
+	// Creates a new managed install CR if it is not available, once the manager started: the creation is validated by
+	// the webhook server of the operator
 	kubeConfig := mgr.GetConfig()
-	client, _ := k8sclient.New(kubeConfig, k8sclient.Options{
+	client, err := k8sclient.New(kubeConfig, k8sclient.Options{
 		Scheme: mgr.GetScheme(),
 	})
-	_, err := r.createPlatformCR(context.Background(), client)
 	if err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return r.bootstrapPlatformCR(ctx, client)
+	})); err != nil {
+		return err
+	}
+	r.recorder = mgr.GetEventRecorderFor("dbaasplatform-controller")
+	r.apiReader = mgr.GetAPIReader()
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dbaasv1alpha1.DBaaSPlatform{}).
-		Complete(r)
+		Owns(&corev1.Service{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		// the provider operator deployments are owned by their CSV, the deployments and config maps are only cached in
+		// the install namespace, see PlatformCacheSelectors
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, handler.EnqueueRequestsFromMapFunc(r.platformsMapFunc)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.platformsMapFunc))
+	if r.Capabilities.OLM {
+		b = b.Owns(&v1alpha1.Subscription{}).
+			Owns(&v1alpha1.ClusterServiceVersion{}, builder.OnlyMetadata)
+	}
+	if r.Capabilities.ConsolePlugins {
+		b = b.Watches(&source.Kind{Type: &consolev1alpha1.ConsolePlugin{}}, handler.EnqueueRequestsFromMapFunc(r.platformsMapFunc)).
+			Watches(&source.Kind{Type: &operatorv1.Console{}}, handler.EnqueueRequestsFromMapFunc(r.platformsMapFunc))
+	}
+	if r.Capabilities.ConsoleQuickStarts {
+		b = b.Watches(&source.Kind{Type: &consolev1.ConsoleQuickStart{}}, handler.EnqueueRequestsFromMapFunc(r.platformsMapFunc))
+	}
+	return b.Complete(r)
+}
+
+// PlatformCacheSelectors restricts the cache of the deployments and config maps watched by the DBaaSPlatform controller
+// to the install namespace, instead of caching every deployment and config map of the cluster
+func PlatformCacheSelectors(installNamespace string) cache.SelectorsByObject {
+	namespaceSelector := fields.OneTermEqualSelector("metadata.namespace", installNamespace)
+	return cache.SelectorsByObject{
+		&appsv1.Deployment{}: {Field: namespaceSelector},
+		&corev1.ConfigMap{}:  {Field: namespaceSelector},
+	}
+}
+
+// platformsMapFunc enqueues the DBaaSPlatform for the changes of the cluster-scoped objects and of the objects of the
+// install namespace it does not own
+func (r *DBaaSPlatformReconciler) platformsMapFunc(obj k8sclient.Object) []reconcile.Request {
+	if obj.GetNamespace() != "" && obj.GetNamespace() != r.InstallNamespace {
+		return nil
+	}
+	platformList := &dbaasv1alpha1.DBaaSPlatformList{}
+	if err := r.List(context.Background(), platformList, k8sclient.InNamespace(r.InstallNamespace)); err != nil {
+		r.Log.Error(err, "Error listing the DBaaSPlatforms", "object", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, platform := range platformList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: k8sclient.ObjectKeyFromObject(&platform)})
+	}
+	return requests
+}
+
+// bootstrapPlatformCR creates the platform CR, retrying until the webhook server serving its validation is up
+func (r *DBaaSPlatformReconciler) bootstrapPlatformCR(ctx context.Context, serverClient k8sclient.Client) error {
+	err := wait.PollImmediateUntil(platformBootstrapInterval, func() (bool, error) {
+		if _, err := r.createPlatformCR(ctx, serverClient); err != nil {
+			r.Log.Error(err, "Error creating the DBaaSPlatform, retrying")
+			return false, nil
+		}
+		return true, nil
+	}, ctx.Done())
+	if err != nil && ctx.Err() != nil {
+		// the manager stopped before the platform CR was created
+		return nil
+	}
+	return err
 }
 
 func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverClient k8sclient.Client) (*dbaasv1alpha1.DBaaSPlatform, error) {
@@ -214,13 +390,16 @@ func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverCl
 			},
 		}
 
-		owner, err := reconcilers.GetDBaaSOperatorCSV(namespace, r.operatorNameVersion, ctx, serverClient)
-		if err != nil {
-			return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
-		}
-		err = ctrl.SetControllerReference(owner, cr, r.Scheme)
-		if err != nil {
-			return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
+		// without OLM, the platform CR is not owned by the operator CSV
+		if r.operatorNameVersion != "" {
+			owner, err := reconcilers.GetDBaaSOperatorCSV(namespace, r.operatorNameVersion, ctx, serverClient)
+			if err != nil {
+				return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
+			}
+			err = ctrl.SetControllerReference(owner, cr, r.Scheme)
+			if err != nil {
+				return nil, fmt.Errorf("could not create dbaas platform intallation CR: %w", err)
+			}
 		}
 
 		err = serverClient.Create(ctx, cr)
@@ -236,15 +415,29 @@ func (r *DBaaSPlatformReconciler) createPlatformCR(ctx context.Context, serverCl
 
 }
 
-func (r *DBaaSPlatformReconciler) getReconcilerForPlatform(platformConfig dbaasv1alpha1.PlatformConfig) reconcilers.PlatformReconciler {
+func (r *DBaaSPlatformReconciler) getReconcilerForPlatform(cr *dbaasv1alpha1.DBaaSPlatform, platform dbaasv1alpha1.PlatformsName, platformConfig dbaasv1alpha1.PlatformConfig) reconcilers.PlatformReconciler {
 	switch platformConfig.Type {
 	case dbaasv1alpha1.TypeProvider:
-		return providers_installation.NewReconciler(r.Client, r.Scheme, r.Log, platformConfig)
+		if !r.Capabilities.OLM {
+			return providers_installation.NewManifestsReconciler(r.Client, r.Scheme, r.Client.RESTMapper(), r.Log, platform, platformConfig)
+		}
+		platformConfig.Image = reconcilers.GetImage(cr.Spec.Images.GetImage(platform), platformConfig.RelatedImageEnv, platformConfig.Image)
+		return providers_installation.NewReconciler(r.Client, r.apiReader, r.Scheme, r.Log, platform, platformConfig)
 	case dbaasv1alpha1.TypeConsolePlugin:
 		if r.OcpVersion != "" && semver.Compare(r.OcpVersion, "v4.9") <= 0 {
 			platformConfig.Image += reconcilers.CONSOLE_PLUGIN_49_TAG
+			platformConfig.RelatedImageEnv += reconcilers.RELATED_IMAGE_49_SUFFIX
 		}
-		return console_plugin.NewReconciler(r.Client, r.Scheme, r.Log, platformConfig)
+		platformConfig.Image = reconcilers.GetImage(cr.Spec.Images.GetImage(platform), platformConfig.RelatedImageEnv, platformConfig.Image)
+		if platform == dbaasv1alpha1.ConsoleTelemetryPluginInstallation && cr.Spec.Telemetry != nil && cr.Spec.Telemetry.SegmentKeyRef != nil {
+			platformConfig.Envs = []corev1.EnvVar{
+				{
+					Name:      reconcilers.CONSOLE_TELEMETRY_PLUGIN_SEGMENT_KEY_ENV,
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: cr.Spec.Telemetry.SegmentKeyRef},
+				},
+			}
+		}
+		return console_plugin.NewReconciler(r.Client, r.Scheme, r.Log, platform, platformConfig)
 	case dbaasv1alpha1.TypeQuickStart:
 		return quickstart_installation.NewReconciler(r.Client, r.Scheme, r.Log)
 	}
@@ -252,7 +445,194 @@ func (r *DBaaSPlatformReconciler) getReconcilerForPlatform(platformConfig dbaasv
 	return nil
 }
 
-func (r *DBaaSPlatformReconciler) updateStatus(cr *dbaasv1alpha1.DBaaSPlatform, nextStatus *dbaasv1alpha1.DBaaSPlatformStatus) (ctrl.Result, error) {
+// getSkipReason returns why a platform is not installed, as the APIs it depends on are not available on the cluster,
+// or an empty string if it is installed
+func (r *DBaaSPlatformReconciler) getSkipReason(cr *dbaasv1alpha1.DBaaSPlatform, platform dbaasv1alpha1.PlatformsName, platformConfig dbaasv1alpha1.PlatformConfig) string {
+	switch platformConfig.Type {
+	case dbaasv1alpha1.TypeProvider:
+		if provider := cr.Spec.GetProvider(platform); !r.Capabilities.OLM && (provider == nil || provider.ManifestsRef == nil) {
+			return "OLM is not available on the cluster, and the provider has no manifests to install"
+		}
+	case dbaasv1alpha1.TypeConsolePlugin:
+		if !r.Capabilities.ConsolePlugins {
+			return "the console plugin APIs are not available on the cluster"
+		}
+	case dbaasv1alpha1.TypeQuickStart:
+		if !r.Capabilities.ConsoleQuickStarts {
+			return "the console quick start API is not available on the cluster"
+		}
+	}
+	return ""
+}
+
+// purgeDBaaSObjects deletes the DBaaS objects of all namespaces in dependency order, the connections, the instances, then
+// the inventories, each kind once the previous one is gone; it returns whether they are all deleted. The deletion is in
+// the foreground, so the DBaaS objects remain until the provider operators deleted the provider resources they own.
+func (r *DBaaSPlatformReconciler) purgeDBaaSObjects(ctx context.Context, nextStatus *dbaasv1alpha1.DBaaSPlatformStatus) (bool, error) {
+	steps := []struct {
+		phase dbaasv1alpha1.UninstallPhase
+		list  k8sclient.ObjectList
+	}{
+		{dbaasv1alpha1.UninstallDeletingConnections, &dbaasv1alpha1.DBaaSConnectionList{}},
+		{dbaasv1alpha1.UninstallDeletingInstances, &dbaasv1alpha1.DBaaSInstanceList{}},
+		{dbaasv1alpha1.UninstallDeletingInventories, &dbaasv1alpha1.DBaaSInventoryList{}},
+	}
+	for _, step := range steps {
+		if err := r.List(ctx, step.list); err != nil {
+			return false, err
+		}
+		items, err := apimeta.ExtractList(step.list)
+		if err != nil {
+			return false, err
+		}
+		if len(items) == 0 {
+			continue
+		}
+		for _, item := range items {
+			obj := item.(k8sclient.Object)
+			if obj.GetDeletionTimestamp() != nil {
+				continue
+			}
+			if err := r.Delete(ctx, obj, k8sclient.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !apierrors.IsNotFound(err) {
+				return false, err
+			}
+		}
+		nextStatus.Uninstall = &dbaasv1alpha1.DBaaSPlatformUninstallStatus{Phase: step.phase, Remaining: len(items)}
+		return false, nil
+	}
+	return true, nil
+}
+
+// getUninstallBlocker returns why a provider platform cannot be uninstalled without purging: the remaining inventories
+// of the provider, or an empty string if it can be uninstalled
+func (r *DBaaSPlatformReconciler) getUninstallBlocker(ctx context.Context, cr *dbaasv1alpha1.DBaaSPlatform, reconciler reconcilers.PlatformReconciler) (string, error) {
+	providerReconciler, ok := reconciler.(reconcilers.ProviderReconciler)
+	if !ok || cr.Spec.IsPurged() {
+		return "", nil
+	}
+	kinds, err := providerReconciler.GetProviderKinds(ctx, cr)
+	if err != nil || len(kinds) == 0 {
+		return "", err
+	}
+
+	providerList := &dbaasv1alpha1.DBaaSProviderList{}
+	if err := r.List(ctx, providerList); err != nil {
+		return "", err
+	}
+	providers := map[string]bool{}
+	for _, provider := range providerList.Items {
+		if contains(kinds, provider.Spec.InventoryKind) {
+			providers[provider.Name] = true
+		}
+	}
+	inventoryList := &dbaasv1alpha1.DBaaSInventoryList{}
+	if err := r.List(ctx, inventoryList); err != nil {
+		return "", err
+	}
+	var inventories []string
+	for _, inventory := range inventoryList.Items {
+		if providers[inventory.Spec.ProviderRef.Name] {
+			inventories = append(inventories, inventory.Namespace+"/"+inventory.Name)
+		}
+	}
+	if len(inventories) == 0 {
+		return "", nil
+	}
+	sort.Strings(inventories)
+	return fmt.Sprintf("the inventories %s of the provider remain", strings.Join(inventories, ", ")), nil
+}
+
+// setUninstallBlocker reports why the uninstallation of a provider platform is blocked
+func setUninstallBlocker(nextStatus *dbaasv1alpha1.DBaaSPlatformStatus, platform dbaasv1alpha1.PlatformsName, blocker string) {
+	for i := range nextStatus.Providers {
+		if nextStatus.Providers[i].Name == platform {
+			nextStatus.Providers[i].UninstallBlocked = blocker
+			return
+		}
+	}
+	nextStatus.SetProviderStatus(dbaasv1alpha1.DBaaSPlatformProviderStatus{Name: platform, UninstallBlocked: blocker})
+}
+
+// setUninstallStatus reports the progress of the uninstallation of all the platforms, deleting the operator group of
+// the provider operators once they are uninstalled
+func (r *DBaaSPlatformReconciler) setUninstallStatus(ctx context.Context, cr *dbaasv1alpha1.DBaaSPlatform, nextStatus *dbaasv1alpha1.DBaaSPlatformStatus, uninstalled bool) error {
+	if cr.Spec.Uninstall == nil {
+		nextStatus.Uninstall = nil
+		return nil
+	}
+	if !uninstalled {
+		nextStatus.Uninstall = &dbaasv1alpha1.DBaaSPlatformUninstallStatus{Phase: dbaasv1alpha1.UninstallUninstallingPlatforms}
+		return nil
+	}
+	if r.Capabilities.OLM {
+		if err := providers_installation.CleanupOperatorGroup(ctx, r.Client); err != nil {
+			return err
+		}
+	}
+	nextStatus.Uninstall = &dbaasv1alpha1.DBaaSPlatformUninstallStatus{Phase: dbaasv1alpha1.UninstallComplete}
+	return nil
+}
+
+// isPlatformDisabled returns whether the DBaaSPlatform disables an optional platform, which is then cleaned up
+func isPlatformDisabled(cr *dbaasv1alpha1.DBaaSPlatform, platform dbaasv1alpha1.PlatformsName) bool {
+	return platform == dbaasv1alpha1.ConsoleTelemetryPluginInstallation && cr.Spec.Telemetry != nil && cr.Spec.Telemetry.Disabled
+}
+
+// checkHealth returns why the installed platforms are degraded, in the order of their names
+func (r *DBaaSPlatformReconciler) checkHealth(ctx context.Context, cr *dbaasv1alpha1.DBaaSPlatform, platforms map[dbaasv1alpha1.PlatformsName]dbaasv1alpha1.PlatformConfig,
+	skipped map[dbaasv1alpha1.PlatformsName]bool) ([]string, error) {
+	names := make([]string, 0, len(platforms))
+	for platform := range platforms {
+		names = append(names, string(platform))
+	}
+	sort.Strings(names)
+
+	var degraded []string
+	for _, name := range names {
+		platform := dbaasv1alpha1.PlatformsName(name)
+		if skipped[platform] || isPlatformDisabled(cr, platform) || cr.Spec.IsUninstalled(platform) {
+			continue
+		}
+		checker, ok := r.getReconcilerForPlatform(cr, platform, platforms[platform]).(reconcilers.PlatformHealthChecker)
+		if !ok {
+			continue
+		}
+		reason, err := checker.CheckHealth(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			degraded = append(degraded, fmt.Sprintf("%s: %s", platform, reason))
+		}
+	}
+	return degraded, nil
+}
+
+// setDegradedCondition reports the degraded platforms, with an event when they change, or that the platforms are
+// healthy once installed
+func (r *DBaaSPlatformReconciler) setDegradedCondition(cr *dbaasv1alpha1.DBaaSPlatform, nextStatus *dbaasv1alpha1.DBaaSPlatformStatus, degraded []string, finished bool) {
+	cond := metav1.Condition{
+		Type:               dbaasv1alpha1.DBaaSPlatformDegradedType,
+		Status:             metav1.ConditionFalse,
+		Reason:             dbaasv1alpha1.Ready,
+		Message:            dbaasv1alpha1.MsgPlatformHealthy,
+		ObservedGeneration: cr.Generation,
+	}
+	if len(degraded) > 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = dbaasv1alpha1.PlatformDegraded
+		cond.Message = strings.Join(degraded, "; ")
+		if previous := apimeta.FindStatusCondition(cr.Status.Conditions, cond.Type); r.recorder != nil &&
+			(previous == nil || previous.Status != cond.Status || previous.Message != cond.Message) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, cond.Reason, cond.Message)
+		}
+	} else if !finished {
+		return
+	}
+	apimeta.SetStatusCondition(&nextStatus.Conditions, cond)
+}
+
+func (r *DBaaSPlatformReconciler) updateStatus(cr *dbaasv1alpha1.DBaaSPlatform, nextStatus *dbaasv1alpha1.DBaaSPlatformStatus, requeue bool) (ctrl.Result, error) {
 	if !reflect.DeepEqual(&cr.Status, nextStatus) {
 		nextStatus.DeepCopyInto(&cr.Status)
 		err := r.Client.Status().Update(context.Background(), cr)
@@ -264,6 +644,9 @@ func (r *DBaaSPlatformReconciler) updateStatus(cr *dbaasv1alpha1.DBaaSPlatform,
 		}
 	}
 
+	if !requeue {
+		return ctrl.Result{}, nil
+	}
 	return ctrl.Result{
 		Requeue:      true,
 		RequeueAfter: RequeueDelaySuccess,
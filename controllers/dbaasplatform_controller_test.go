@@ -1,10 +1,21 @@
 package controllers
 
 import (
+	"context"
+	"testing"
+
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
 )
 
 var _ = Describe("DBaaSPlatform controller", func() {
@@ -46,3 +57,126 @@ var _ = Describe("DBaaSPlatform controller", func() {
 
 	})
 })
+
+func TestPlatformSkipReason(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	cr := &dbaasv1alpha1.DBaaSPlatform{
+		Spec: dbaasv1alpha1.DBaaSPlatformSpec{
+			Providers: []dbaasv1alpha1.DBaaSPlatformProvider{
+				{Name: dbaasv1alpha1.CrunchyBridgeInstallation, ManifestsRef: &corev1.LocalObjectReference{Name: "crunchy-bridge-manifests"}},
+			},
+		},
+	}
+	skipReason := func(r *DBaaSPlatformReconciler, platform dbaasv1alpha1.PlatformsName) string {
+		return r.getSkipReason(cr, platform, reconcilers.InstallationPlatforms[platform])
+	}
+
+	openShift := &DBaaSPlatformReconciler{
+		Capabilities: reconcilers.ClusterCapabilities{OLM: true, ConsolePlugins: true, ConsoleQuickStarts: true},
+	}
+	for platform := range reconcilers.InstallationPlatforms {
+		Expect(skipReason(openShift, platform)).Should(BeEmpty())
+	}
+
+	kubernetes := &DBaaSPlatformReconciler{}
+	Expect(skipReason(kubernetes, dbaasv1alpha1.CrunchyBridgeInstallation)).Should(BeEmpty())
+	Expect(skipReason(kubernetes, dbaasv1alpha1.MongoDBAtlasInstallation)).Should(Equal("OLM is not available on the cluster, and the provider has no manifests to install"))
+	Expect(skipReason(kubernetes, dbaasv1alpha1.DBaaSDynamicPluginInstallation)).Should(Equal("the console plugin APIs are not available on the cluster"))
+	Expect(skipReason(kubernetes, dbaasv1alpha1.DBaaSQuickStartInstallation)).Should(Equal("the console quick start API is not available on the cluster"))
+}
+
+func TestPlatformDegradedCondition(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &DBaaSPlatformReconciler{recorder: recorder}
+	cr := &dbaasv1alpha1.DBaaSPlatform{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	setDegradedCondition := func(degraded []string, finished bool) *metav1.Condition {
+		nextStatus := cr.Status.DeepCopy()
+		r.setDegradedCondition(cr, nextStatus, degraded, finished)
+		nextStatus.DeepCopyInto(&cr.Status)
+		return apimeta.FindStatusCondition(cr.Status.Conditions, dbaasv1alpha1.DBaaSPlatformDegradedType)
+	}
+
+	// the health is reported once the platforms are installed
+	Expect(setDegradedCondition(nil, false)).Should(BeNil())
+	cond := setDegradedCondition(nil, true)
+	Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+	Expect(cond.Message).Should(Equal(dbaasv1alpha1.MsgPlatformHealthy))
+	Expect(cond.ObservedGeneration).Should(Equal(int64(2)))
+
+	degraded := []string{"dbaas-dynamic-plugin: the console plugin dbaas-dynamic-plugin is not enabled in the console"}
+	cond = setDegradedCondition(degraded, false)
+	Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+	Expect(cond.Reason).Should(Equal(dbaasv1alpha1.PlatformDegraded))
+	Expect(cond.Message).Should(Equal(degraded[0]))
+	Expect(recorder.Events).Should(Receive(Equal("Warning PlatformDegraded " + degraded[0])))
+
+	// the event is only recorded when the degradation changes
+	setDegradedCondition(degraded, true)
+	Expect(recorder.Events).ShouldNot(Receive())
+
+	// the condition is kept while the repaired platforms are reinstalled
+	Expect(setDegradedCondition(nil, false).Status).Should(Equal(metav1.ConditionTrue))
+	Expect(setDegradedCondition(nil, true).Status).Should(Equal(metav1.ConditionFalse))
+}
+
+type fakeProviderReconciler struct {
+	reconcilers.PlatformReconciler
+	kinds []string
+}
+
+func (r *fakeProviderReconciler) GetProviderKinds(ctx context.Context, cr *dbaasv1alpha1.DBaaSPlatform) ([]string, error) {
+	return r.kinds, nil
+}
+
+func TestPlatformUninstall(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	Expect(dbaasv1alpha1.AddToScheme(scheme)).Should(Succeed())
+	provider := &dbaasv1alpha1.DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "atlas-provider-registration"},
+		Spec:       dbaasv1alpha1.DBaaSProviderSpec{InventoryKind: "AtlasInventory"},
+	}
+	inventory := &dbaasv1alpha1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "team-a"},
+		Spec: dbaasv1alpha1.DBaaSOperatorInventorySpec{
+			ProviderRef: dbaasv1alpha1.NamespacedName{Name: provider.Name},
+		},
+	}
+	connection := &dbaasv1alpha1.DBaaSConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "connection", Namespace: "team-b"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(provider, inventory, connection).Build()
+	r := &DBaaSPlatformReconciler{DBaaSReconciler: &DBaaSReconciler{Client: c, Scheme: scheme}, Log: ctrl.Log}
+
+	// the providers with remaining inventories are not uninstalled without purge
+	cr := &dbaasv1alpha1.DBaaSPlatform{Spec: dbaasv1alpha1.DBaaSPlatformSpec{Uninstall: &dbaasv1alpha1.DBaaSPlatformUninstall{}}}
+	Expect(r.getUninstallBlocker(ctx, cr, &fakeProviderReconciler{kinds: []string{"AtlasInventory"}})).
+		Should(Equal("the inventories team-a/inventory of the provider remain"))
+	Expect(r.getUninstallBlocker(ctx, cr, &fakeProviderReconciler{kinds: []string{"CrunchyBridgeInventory"}})).Should(BeEmpty())
+
+	// the DBaaS objects are purged in dependency order
+	cr.Spec.Uninstall.Purge = true
+	Expect(r.getUninstallBlocker(ctx, cr, &fakeProviderReconciler{kinds: []string{"AtlasInventory"}})).Should(BeEmpty())
+	status := &dbaasv1alpha1.DBaaSPlatformStatus{}
+	Expect(r.purgeDBaaSObjects(ctx, status)).Should(BeFalse())
+	Expect(status.Uninstall).Should(Equal(&dbaasv1alpha1.DBaaSPlatformUninstallStatus{Phase: dbaasv1alpha1.UninstallDeletingConnections, Remaining: 1}))
+	Expect(r.purgeDBaaSObjects(ctx, status)).Should(BeFalse())
+	Expect(status.Uninstall).Should(Equal(&dbaasv1alpha1.DBaaSPlatformUninstallStatus{Phase: dbaasv1alpha1.UninstallDeletingInventories, Remaining: 1}))
+	Expect(r.purgeDBaaSObjects(ctx, status)).Should(BeTrue())
+
+	Expect(r.setUninstallStatus(ctx, cr, status, false)).Should(Succeed())
+	Expect(status.Uninstall.Phase).Should(Equal(dbaasv1alpha1.UninstallUninstallingPlatforms))
+	Expect(r.setUninstallStatus(ctx, cr, status, true)).Should(Succeed())
+	Expect(status.Uninstall.Phase).Should(Equal(dbaasv1alpha1.UninstallComplete))
+	cr.Spec.Uninstall = nil
+	Expect(r.setUninstallStatus(ctx, cr, status, true)).Should(Succeed())
+	Expect(status.Uninstall).Should(BeNil())
+}
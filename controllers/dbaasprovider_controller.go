@@ -18,8 +18,11 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -35,6 +38,7 @@ type DBaaSProviderReconciler struct {
 	ConnectionCtrl controller.Controller
 	InventoryCtrl  controller.Controller
 	InstanceCtrl   controller.Controller
+	WatchRegistry  *ProviderWatchRegistry
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -52,35 +56,67 @@ func (r *DBaaSProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	var provider v1alpha1.DBaaSProvider
 	if err := r.Get(ctx, req.NamespacedName, &provider); err != nil {
 		if errors.IsNotFound(err) {
-			// CR deleted since request queued, child objects getting GC'd, no requeue
+			// CR deleted since request queued, stop watching the provider CRs no other provider uses, no requeue
 			logger.V(1).Info("DBaaS Provider resource not found, has been deleted")
+			if err := r.WatchRegistry.SetProviderWatches(req.Name, nil); err != nil {
+				logger.Error(err, "Error releasing the watches of the deleted DBaaS Provider")
+				return ctrl.Result{}, err
+			}
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Error fetching DBaaS Provider for reconcile")
 		return ctrl.Result{}, err
 	}
 
-	if err := r.watchDBaaSProviderObject(r.InventoryCtrl, &v1alpha1.DBaaSInventory{}, provider.Spec.InventoryKind); err != nil {
-		logger.Error(err, "Error watching Provider Inventory CR", "Kind", provider.Spec.InventoryKind)
-		return ctrl.Result{}, err
+	watches := []ProviderWatch{
+		{Controller: r.InventoryCtrl, Owner: &v1alpha1.DBaaSInventory{}, Kind: provider.Spec.InventoryKind},
+		{Controller: r.ConnectionCtrl, Owner: &v1alpha1.DBaaSConnection{}, Kind: provider.Spec.ConnectionKind},
+		{Controller: r.InstanceCtrl, Owner: &v1alpha1.DBaaSInstance{}, Kind: provider.Spec.InstanceKind},
 	}
-	logger.Info("Watching Provider Inventory CR", "Kind", provider.Spec.InventoryKind)
-
-	if err := r.watchDBaaSProviderObject(r.ConnectionCtrl, &v1alpha1.DBaaSConnection{}, provider.Spec.ConnectionKind); err != nil {
-		logger.Error(err, "Error watching Provider Connection CR", "Kind", provider.Spec.ConnectionKind)
+	if err := r.WatchRegistry.SetProviderWatches(provider.Name, watches); err != nil {
+		logger.Error(err, "Error watching Provider CRs", "Inventory Kind", provider.Spec.InventoryKind,
+			"Connection Kind", provider.Spec.ConnectionKind, "Instance Kind", provider.Spec.InstanceKind)
+		cond := metav1.Condition{
+			Type:    v1alpha1.DBaaSProviderWatchesReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.ProviderWatchFailed,
+			Message: fmt.Sprintf("Failed to watch the provider Custom Resources: %v", err),
+		}
+		if statusErr := r.updateProviderStatus(ctx, &provider, cond); statusErr != nil {
+			logger.Error(statusErr, "Error updating the DBaaS Provider status")
+		}
 		return ctrl.Result{}, err
 	}
-	logger.Info("Watching Provider Connection CR", "Kind", provider.Spec.ConnectionKind)
-
-	if err := r.watchDBaaSProviderObject(r.InstanceCtrl, &v1alpha1.DBaaSInstance{}, provider.Spec.InstanceKind); err != nil {
-		logger.Error(err, "Error watching Provider Instance CR", "Kind", provider.Spec.InstanceKind)
+	logger.Info("Watching Provider CRs", "Inventory Kind", provider.Spec.InventoryKind,
+		"Connection Kind", provider.Spec.ConnectionKind, "Instance Kind", provider.Spec.InstanceKind)
+
+	cond := metav1.Condition{
+		Type:    v1alpha1.DBaaSProviderWatchesReadyType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.Ready,
+		Message: v1alpha1.MsgProviderWatchesReady,
+	}
+	if err := r.updateProviderStatus(ctx, &provider, cond); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Provider modified, retry reconciling")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Provider status")
 		return ctrl.Result{}, err
 	}
-	logger.Info("Watching Provider Instance CR", "Kind", provider.Spec.InstanceKind)
 
 	return ctrl.Result{}, nil
 }
 
+// sets the provider watches condition, for the observed generation, and surfaces the provider capabilities
+func (r *DBaaSProviderReconciler) updateProviderStatus(ctx context.Context, provider *v1alpha1.DBaaSProvider, cond metav1.Condition) error {
+	cond.ObservedGeneration = provider.Generation
+	apimeta.SetStatusCondition(&provider.Status.Conditions, cond)
+	capabilities := provider.Spec.GetCapabilities()
+	provider.Status.Capabilities = &capabilities
+	return r.Client.Status().Update(ctx, provider)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -96,7 +132,7 @@ var filterEventPredicate = predicate.Funcs{
 		return updateEvent.ObjectNew.GetGeneration() != updateEvent.ObjectOld.GetGeneration()
 	},
 	DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-		return false
+		return true
 	},
 	GenericFunc: func(genericEvent event.GenericEvent) bool {
 		return false
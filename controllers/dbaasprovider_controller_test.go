@@ -20,10 +20,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -86,6 +88,28 @@ var _ = Describe("DBaaSProvider controller", func() {
 			It("should make DBaaSInventory, DBaaSConnection and DBaaSInstance watch the provider inventory, connection and instance", func() {
 				assertWatched(iSrc, iOwner, cSrc, cOwner, inSrc, inOwner)
 			})
+			It("should report the provider watches as ready in the DBaaSProvider status", func() {
+				Eventually(func() metav1.ConditionStatus {
+					pProvider := &v1alpha1.DBaaSProvider{}
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(provider), pProvider); err != nil {
+						return metav1.ConditionUnknown
+					}
+					cond := apimeta.FindStatusCondition(pProvider.Status.Conditions, v1alpha1.DBaaSProviderWatchesReadyType)
+					if cond == nil {
+						return metav1.ConditionUnknown
+					}
+					return cond.Status
+				}, timeout).Should(Equal(metav1.ConditionTrue))
+			})
+			It("should report the default provider capabilities in the DBaaSProvider status", func() {
+				Eventually(func() *v1alpha1.DBaaSProviderCapabilities {
+					pProvider := &v1alpha1.DBaaSProvider{}
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(provider), pProvider); err != nil {
+						return nil
+					}
+					return pProvider.Status.Capabilities
+				}, timeout).Should(Equal(&v1alpha1.DBaaSProviderCapabilities{Provisioning: pointer.Bool(true), Deletion: pointer.Bool(true)}))
+			})
 		})
 
 		Context("after updating a DBaaSProvider", func() {
@@ -139,6 +163,19 @@ var _ = Describe("DBaaSProvider controller", func() {
 				}, timeout).Should(Equal(updatedProvider.Spec))
 
 				assertWatched(uiSrc, iOwner, ucSrc, cOwner, uinSrc, inOwner)
+				Eventually(func() []string {
+					var watchedKinds []string
+					for _, watch := range watchRegistry.ActiveWatches() {
+						if contains(watch.Providers, provider.Name) {
+							watchedKinds = append(watchedKinds, watch.GroupVersionKind)
+						}
+					}
+					return watchedKinds
+				}, timeout).Should(ConsistOf(
+					providerGVK(updatedInventoryKind).String(),
+					providerGVK(updatedConnectionKind).String(),
+					providerGVK(updatedInstanceKind).String(),
+				))
 			})
 		})
 	})
@@ -200,6 +237,15 @@ var _ = Describe("DBaaSProvider controller", func() {
 
 				assertResourceDeletion(provider)()
 				assertNotWatched(iSrc, iOwner, cSrc, cOwner, inSrc, inOwner)
+				Eventually(func() []ActiveProviderWatch {
+					var providerWatches []ActiveProviderWatch
+					for _, watch := range watchRegistry.ActiveWatches() {
+						if watch.GroupVersionKind == providerGVK(deletedInventoryKind).String() {
+							providerWatches = append(providerWatches, watch)
+						}
+					}
+					return providerWatches
+				}, timeout).Should(BeEmpty())
 			})
 		})
 	})
@@ -96,12 +96,6 @@ func (r *DBaaSTenantReconciler) reconcileTenantAuthz(ctx context.Context, tenant
 	//
 	// Tenant RBAC
 	//
-	serviceAdminAuthz := r.getServiceAdminAuthz(ctx, tenant.Spec.InventoryNamespace)
-	developerAuthz := r.getDeveloperAuthz(ctx, tenant.Spec.InventoryNamespace, inventoryList)
-	tenantListAuthz := r.getTenantListAuthz(ctx)
-	if err := r.reconcileTenantRbacObjs(ctx, tenant, serviceAdminAuthz, developerAuthz, tenantListAuthz); err != nil {
-		return err
-	}
-
-	return nil
+	serviceAdminAuthz, developerAuthz, tenantListAuthz, reviewErr := r.getTenantAuthz(ctx, tenant.Spec.InventoryNamespace, inventoryList)
+	return r.reconcileTenant(ctx, tenant, inventoryList, serviceAdminAuthz, developerAuthz, tenantListAuthz, reviewErr)
 }
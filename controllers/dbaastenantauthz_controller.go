@@ -18,17 +18,24 @@ package controllers
 
 import (
 	"context"
+	"reflect"
+	"time"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -41,7 +48,8 @@ type DBaaSTenantAuthzReconciler struct {
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
-//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles/finalizers;clusterrolebindings/finalizers,verbs=update
 //+kubebuilder:rbac:groups="";authorization.openshift.io,resources=localresourceaccessreviews;localsubjectaccessreviews;resourceaccessreviews;selfsubjectrulesreviews;subjectaccessreviews;subjectrulesreviews,verbs=create
 
@@ -66,6 +74,12 @@ func (r *DBaaSTenantAuthzReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSTenantAuthzReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.apiReader = mgr.GetAPIReader()
+	if cachedBackend, ok := r.AuthzBackend.(*CachedAuthzBackend); ok {
+		if err := cachedBackend.SetupWithManager(mgr); err != nil {
+			return err
+		}
+	}
 	if err := ctrl.NewControllerManagedBy(mgr).
 		Named("dbaasauthz").
 		// set tenant as main object watched by controller, but ignore all events
@@ -74,28 +88,47 @@ func (r *DBaaSTenantAuthzReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// all inventory events should trigger full authz reconcile
 		Watches(
 			&source.Kind{Type: &v1alpha1.DBaaSInventory{}},
-			handler.EnqueueRequestsFromMapFunc(nsMapFunc),
+			enqueueCoalescedRequestsFromMapFunc(nsMapFunc),
 		).
-		// all role events should trigger full authz reconcile
+		// access reports are read-only: they are regenerated if deleted, and their status is overwritten if modified
+		Watches(
+			&source.Kind{Type: &v1alpha1.DBaaSAccessReport{}},
+			enqueueCoalescedRequestsFromMapFunc(nsMapFunc),
+			builder.WithPredicates(accessReportChangedPredicate),
+		).
+		// role rule changes trigger an authz reconcile of their namespace, and of the tenant namespaces
+		// ... whose inventories allow it as a connection namespace
 		Watches(
 			&source.Kind{Type: &rbacv1.Role{}},
-			handler.EnqueueRequestsFromMapFunc(nsMapFunc),
+			enqueueCoalescedRequestsFromMapFunc(r.connectionNSMapFunc),
+			builder.WithPredicates(roleRulesChangedPredicate),
 		).
-		// all rolebinding events should trigger full authz reconcile
+		// rolebinding changes trigger an authz reconcile of their namespace, and of the tenant namespaces
+		// ... whose inventories allow it as a connection namespace
 		Watches(
 			&source.Kind{Type: &rbacv1.RoleBinding{}},
-			handler.EnqueueRequestsFromMapFunc(nsMapFunc),
+			enqueueCoalescedRequestsFromMapFunc(r.connectionNSMapFunc),
 			// for most rolebindings, to reduce memory footprint, only cache metadata
 			builder.OnlyMetadata,
+			// rbac objects have no generation, resyncs are ignored
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		// namespace creations, deletions and label changes may add or remove connection namespaces of tenant inventories
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			enqueueCoalescedRequestsFromMapFunc(r.connectionNSMapFunc),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
 		).
-		// all tenant events should trigger full authz reconcile
+		// all tenant spec events should trigger full authz reconcile
 		// ... tenant events are transformed to pass inventory namespace in request
+		// ... status updates are ignored, as the status is written by this controller
 		Watches(
 			&source.Kind{Type: &v1alpha1.DBaaSTenant{}},
-			handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+			enqueueCoalescedRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
 				namespace := o.(*v1alpha1.DBaaSTenant).Spec.InventoryNamespace
 				return getRequest(namespace)
 			}),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 6},
@@ -123,6 +156,145 @@ var nsMapFunc = func(o client.Object) []reconcile.Request {
 	return getRequest(namespace)
 }
 
+// the period over which the events requesting the same namespace are coalesced into a single reconcile
+const authzCoalescePeriod = time.Second
+
+// enqueues the requests of a map function after the coalesce period: the requests of the events received in the
+// meantime are then deduplicated by the workqueue, so bursts of RBAC, inventory or tenant events in a namespace
+// trigger a single authz reconcile
+func enqueueCoalescedRequestsFromMapFunc(mapFn handler.MapFunc) handler.EventHandler {
+	enqueue := func(q workqueue.RateLimitingInterface, objects ...client.Object) {
+		for _, o := range objects {
+			for _, req := range mapFn(o) {
+				q.AddAfter(req, authzCoalescePeriod)
+			}
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, e.Object)
+		},
+	}
+}
+
+// only role updates changing the rules trigger a reconcile
+var roleRulesChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldRole, oldOK := e.ObjectOld.(*rbacv1.Role)
+		newRole, newOK := e.ObjectNew.(*rbacv1.Role)
+		return !oldOK || !newOK || !reflect.DeepEqual(oldRole.Rules, newRole.Rules)
+	},
+}
+
+// only process the deletion of access reports, and the updates of their status, which include the writes of this
+// controller: those are filtered out by the reconcile, as the regenerated status is then unchanged
+var accessReportChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldReport, oldOK := e.ObjectOld.(*v1alpha1.DBaaSAccessReport)
+		newReport, newOK := e.ObjectNew.(*v1alpha1.DBaaSAccessReport)
+		return !oldOK || !newOK || !reflect.DeepEqual(oldReport.Status, newReport.Status)
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+// requests the object's namespace if it is a tenant inventory namespace, along with the inventory namespaces of the
+// tenants whose inventories allow the object's namespace as a connection namespace. The old and new objects of an
+// update are both mapped, so namespaces which stop matching a selector are requested too.
+func (r *DBaaSTenantAuthzReconciler) connectionNSMapFunc(o client.Object) []reconcile.Request {
+	ctx := context.Background()
+	namespace := o.GetNamespace()
+	var nsLabels map[string]string
+	nsFetched := false
+	ns, isNamespace := o.(*corev1.Namespace)
+	if isNamespace {
+		namespace = ns.Name
+		nsLabels = ns.Labels
+		nsFetched = true
+	}
+
+	var tenantList v1alpha1.DBaaSTenantList
+	if err := r.List(ctx, &tenantList); err != nil {
+		return nil
+	}
+	tenantsByNS := map[string][]v1alpha1.DBaaSTenant{}
+	for _, tenant := range tenantList.Items {
+		tenantsByNS[tenant.Spec.InventoryNamespace] = append(tenantsByNS[tenant.Spec.InventoryNamespace], tenant)
+	}
+
+	requested := map[string]bool{}
+	var requests []reconcile.Request
+	request := func(nameNS string) {
+		if !requested[nameNS] {
+			requested[nameNS] = true
+			requests = append(requests, getRequest(nameNS)...)
+		}
+	}
+	if _, found := tenantsByNS[namespace]; found {
+		request(namespace)
+	}
+
+	var inventoryList v1alpha1.DBaaSInventoryList
+	if err := r.List(ctx, &inventoryList); err != nil {
+		return requests
+	}
+	inventoryNamespaces := map[string]bool{}
+	for i := range inventoryList.Items {
+		inventory := &inventoryList.Items[i]
+		inventoryNamespaces[inventory.Namespace] = true
+		tenants, found := tenantsByNS[inventory.Namespace]
+		if !found || requested[inventory.Namespace] {
+			continue
+		}
+		validNamespaces, selectors := getConnectionNSRules(inventory, tenants)
+		if contains(validNamespaces, "*") || contains(validNamespaces, namespace) {
+			request(inventory.Namespace)
+			continue
+		}
+		if len(selectors) == 0 {
+			continue
+		}
+		if !nsFetched {
+			ns := &corev1.Namespace{}
+			if err := r.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+				continue
+			}
+			nsLabels = ns.Labels
+			nsFetched = true
+		}
+		if matches, err := matchesAnySelector(selectors, nsLabels); err == nil && matches {
+			request(inventory.Namespace)
+		}
+	}
+
+	// the status of the tenants without inventories lists the namespaces matching the tenant selectors
+	if isNamespace {
+		for inventoryNS, tenants := range tenantsByNS {
+			if inventoryNamespaces[inventoryNS] || requested[inventoryNS] {
+				continue
+			}
+			_, selectors := getConnectionNSRules(&v1alpha1.DBaaSInventory{}, tenants)
+			if matches, err := matchesAnySelector(selectors, ns.Labels); err == nil && matches {
+				request(inventoryNS)
+			}
+		}
+	}
+	return requests
+}
+
 func getRequest(nameNS string) []reconcile.Request {
 	return []reconcile.Request{
 		{
@@ -157,14 +329,29 @@ func (r *DBaaSTenantAuthzReconciler) reconcileAuthz(ctx context.Context, namespa
 		//
 		// Tenant RBAC
 		//
-		serviceAdminAuthz := r.getServiceAdminAuthz(ctx, namespace)
-		developerAuthz := r.getDeveloperAuthz(ctx, namespace, inventoryList)
-		tenantListAuthz := r.getTenantListAuthz(ctx)
+		// a failed tenant, whose status reports the error, doesn't prevent reconciling the other tenants and inventories
+		var errs []error
+		serviceAdminAuthz, developerAuthz, tenantListAuthz, reviewErr := r.getTenantAuthz(ctx, namespace, inventoryList)
 		for _, tenant := range tenantList.Items {
-			if err := r.reconcileTenantRbacObjs(ctx, tenant, serviceAdminAuthz, developerAuthz, tenantListAuthz); err != nil {
-				return err
+			if err := r.reconcileTenant(ctx, tenant, inventoryList, serviceAdminAuthz, developerAuthz, tenantListAuthz, reviewErr); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		//
+		// Inventory RBAC & access reports
+		//
+		nsAuthz := newConnectionNSAuthz(tenantList.Items)
+		for _, inventory := range inventoryList.Items {
+			if err := r.reconcileInventoryRbacObjs(ctx, inventory, nsAuthz); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if err := r.reconcileAccessReport(ctx, inventory, tenantList.Items); err != nil {
+				errs = append(errs, err)
 			}
 		}
+		return utilerrors.Reduce(utilerrors.NewAggregate(errs))
 	}
 
 	return nil
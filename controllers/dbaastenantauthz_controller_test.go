@@ -17,6 +17,9 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -24,7 +27,16 @@ import (
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	oauthzv1 "github.com/openshift/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 func TestTenantRbacObjs(t *testing.T) {
@@ -178,3 +190,476 @@ func TestTenantRbacObjs(t *testing.T) {
 
 	Expect(removeFromSlice([]string{"user6"}, resultingSlice)).To(Equal([]string{"user1"}))
 }
+
+func TestInventoryRbacObjs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	inventory := v1alpha1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-inventory", Namespace: "test-namespace"},
+	}
+	roleName := "dbaas-" + inventory.Name + "-inventory-viewer"
+
+	// no connection namespace developers
+	role, roleBinding := inventoryRbacObjs(inventory, &oauthzv1.ResourceAccessReviewResponse{})
+	Expect(role.Name).To(Equal(roleName))
+	Expect(role.Namespace).To(Equal(inventory.Namespace))
+	Expect(role.Rules).To(HaveLen(1))
+	Expect(role.Rules[0].ResourceNames).To(Equal([]string{inventory.Name}))
+	Expect(role.Rules[0].Verbs).To(Equal([]string{"get"}))
+	Expect(roleBinding.Name).To(Equal(roleName + "s"))
+	Expect(roleBinding.Namespace).To(Equal(inventory.Namespace))
+	Expect(roleBinding.RoleRef.Kind).To(Equal("Role"))
+	Expect(roleBinding.RoleRef.Name).To(Equal(roleName))
+	Expect(roleBinding.Subjects).To(BeNil())
+
+	// connection namespace developers
+	connectionNSAuthz := &oauthzv1.ResourceAccessReviewResponse{
+		UsersSlice:  []string{"user1", "system:serviceaccount:connection-namespace:connection-sa"},
+		GroupsSlice: []string{"group1"},
+	}
+	_, roleBinding = inventoryRbacObjs(inventory, connectionNSAuthz)
+	Expect(roleBinding.Subjects).To(HaveLen(3))
+	Expect(roleBinding.Subjects[0].Name).To(Equal("user1"))
+	Expect(roleBinding.Subjects[0].Kind).To(Equal("User"))
+	Expect(roleBinding.Subjects[1].Name).To(Equal("connection-sa"))
+	Expect(roleBinding.Subjects[1].Kind).To(Equal("ServiceAccount"))
+	Expect(roleBinding.Subjects[1].Namespace).To(Equal("connection-namespace"))
+	Expect(roleBinding.Subjects[2].Name).To(Equal("group1"))
+	Expect(roleBinding.Subjects[2].Kind).To(Equal("Group"))
+}
+
+func TestSetTenantStatus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	tenant := v1alpha1.DBaaSTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2},
+		Spec: v1alpha1.DBaaSTenantSpec{
+			InventoryNamespace:   "test-ns",
+			ConnectionNamespaces: []string{"dev-ns"},
+		},
+	}
+	inventoryList := v1alpha1.DBaaSInventoryList{
+		Items: []v1alpha1.DBaaSInventory{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test-ns"},
+				Spec: v1alpha1.DBaaSOperatorInventorySpec{
+					ConnectionNamespaces: []string{"other-ns"},
+				},
+				Status: v1alpha1.DBaaSInventoryStatus{
+					Conditions: []metav1.Condition{{Type: v1alpha1.DBaaSInventoryReadyType, Status: metav1.ConditionTrue}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "not-ready", Namespace: "test-ns"},
+			},
+		},
+	}
+	serviceAdminAuthz := &oauthzv1.ResourceAccessReviewResponse{UsersSlice: []string{"admin2", "admin1"}}
+	developerAuthz := &oauthzv1.ResourceAccessReviewResponse{UsersSlice: []string{"dev1"}, GroupsSlice: []string{"devs"}}
+	connectionNamespaces := []string{"dev-ns", "other-ns", "test-ns"}
+
+	// successful rbac reconcile
+	status := &v1alpha1.DBaaSTenantStatus{}
+	setTenantStatus(status, tenant, inventoryList, connectionNamespaces, serviceAdminAuthz, developerAuthz, nil, nil)
+	Expect(status.ServiceAdmins).To(Equal(v1alpha1.DBaaSTenantSubjects{Users: []string{"admin1", "admin2"}}))
+	Expect(status.Developers).To(Equal(v1alpha1.DBaaSTenantSubjects{Users: []string{"dev1"}, Groups: []string{"devs"}}))
+	Expect(status.Inventories).To(Equal(v1alpha1.DBaaSTenantInventoryCounts{Total: 2, Ready: 1, NotReady: 1}))
+	Expect(status.ConnectionNamespaces).To(Equal([]string{"dev-ns", "other-ns", "test-ns"}))
+	authzCond := apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantAuthzSyncedType)
+	Expect(authzCond).NotTo(BeNil())
+	Expect(authzCond.Status).To(Equal(metav1.ConditionTrue))
+	Expect(authzCond.ObservedGeneration).To(Equal(int64(2)))
+	readyCond := apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantReadyType)
+	Expect(readyCond).NotTo(BeNil())
+	Expect(readyCond.Status).To(Equal(metav1.ConditionTrue))
+	Expect(readyCond.ObservedGeneration).To(Equal(int64(2)))
+
+	// wildcard connection namespace & no ready inventories
+	connectionNamespaces = []string{"*"}
+	inventoryList.Items[0].Status.Conditions = nil
+	setTenantStatus(status, tenant, inventoryList, connectionNamespaces, serviceAdminAuthz, developerAuthz, nil, nil)
+	Expect(status.Inventories).To(Equal(v1alpha1.DBaaSTenantInventoryCounts{Total: 2, Ready: 0, NotReady: 2}))
+	Expect(status.ConnectionNamespaces).To(Equal([]string{"*"}))
+	readyCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantReadyType)
+	Expect(readyCond.Status).To(Equal(metav1.ConditionFalse))
+	Expect(readyCond.Reason).To(Equal(v1alpha1.NoReadyInventories))
+
+	// failed rbac reconcile
+	setTenantStatus(status, tenant, inventoryList, connectionNamespaces, serviceAdminAuthz, developerAuthz, nil, fmt.Errorf("rbac error"))
+	authzCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantAuthzSyncedType)
+	Expect(authzCond.Status).To(Equal(metav1.ConditionFalse))
+	Expect(authzCond.Reason).To(Equal(v1alpha1.AuthzSyncFailed))
+	Expect(authzCond.Message).To(Equal("rbac error"))
+	readyCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantReadyType)
+	Expect(readyCond.Status).To(Equal(metav1.ConditionFalse))
+	Expect(readyCond.Reason).To(Equal(v1alpha1.AuthzSyncFailed))
+	degradedCond := apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantDegradedType)
+	Expect(degradedCond.Status).To(Equal(metav1.ConditionTrue))
+	Expect(degradedCond.Reason).To(Equal(v1alpha1.AuthzSyncFailed))
+
+	// failed access reviews keep the last known-good subjects
+	inventoryList.Items[0].Status.Conditions = []metav1.Condition{{Type: v1alpha1.DBaaSInventoryReadyType, Status: metav1.ConditionTrue}}
+	setTenantStatus(status, tenant, inventoryList, connectionNamespaces, nil, nil, fmt.Errorf("review error"), nil)
+	Expect(status.ServiceAdmins).To(Equal(v1alpha1.DBaaSTenantSubjects{Users: []string{"admin1", "admin2"}}))
+	Expect(status.Developers).To(Equal(v1alpha1.DBaaSTenantSubjects{Users: []string{"dev1"}, Groups: []string{"devs"}}))
+	authzCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantAuthzSyncedType)
+	Expect(authzCond.Status).To(Equal(metav1.ConditionFalse))
+	Expect(authzCond.Reason).To(Equal(v1alpha1.AccessReviewFailed))
+	degradedCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantDegradedType)
+	Expect(degradedCond.Status).To(Equal(metav1.ConditionTrue))
+	Expect(degradedCond.Reason).To(Equal(v1alpha1.AccessReviewFailed))
+	readyCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantReadyType)
+	Expect(readyCond.Status).To(Equal(metav1.ConditionTrue))
+
+	// recovered access reviews clear the degraded condition
+	setTenantStatus(status, tenant, inventoryList, connectionNamespaces, serviceAdminAuthz, developerAuthz, nil, nil)
+	degradedCond = apimeta.FindStatusCondition(status.Conditions, v1alpha1.DBaaSTenantDegradedType)
+	Expect(degradedCond.Status).To(Equal(metav1.ConditionFalse))
+	Expect(degradedCond.Reason).To(Equal(v1alpha1.NotDegraded))
+	Expect(degradedCond.Message).To(Equal(v1alpha1.MsgTenantNotDegraded))
+}
+
+func TestReconcileAuthzWithFailedTenants(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+	Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+	tenants := []client.Object{
+		&v1alpha1.DBaaSTenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-1"}, Spec: v1alpha1.DBaaSTenantSpec{InventoryNamespace: "test-ns"}},
+		&v1alpha1.DBaaSTenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-2"}, Spec: v1alpha1.DBaaSTenantSpec{InventoryNamespace: "test-ns"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tenants...).Build()
+	r := &DBaaSTenantAuthzReconciler{
+		DBaaSAuthzReconciler: &DBaaSAuthzReconciler{
+			DBaaSReconciler: &DBaaSReconciler{Client: c, Scheme: scheme},
+			AuthzBackend:    &countingAuthzBackend{err: fmt.Errorf("review failed")},
+		},
+	}
+
+	// the failed access reviews are reported by all the tenants, and returned to be retried
+	Expect(r.reconcileAuthz(context.Background(), "test-ns")).To(MatchError("review failed"))
+	for _, tenant := range tenants {
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(tenant), tenant)).To(Succeed())
+		degradedCond := apimeta.FindStatusCondition(tenant.(*v1alpha1.DBaaSTenant).Status.Conditions, v1alpha1.DBaaSTenantDegradedType)
+		Expect(degradedCond).NotTo(BeNil())
+		Expect(degradedCond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degradedCond.Reason).To(Equal(v1alpha1.AccessReviewFailed))
+	}
+}
+
+func TestGetConnectionNamespaces(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+	Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+	tenant := &v1alpha1.DBaaSTenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.DBaaSTenantSpec{
+			InventoryNamespace:          "test-ns",
+			ConnectionNamespaces:        []string{"dev-ns"},
+			ConnectionNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "dev"}},
+		},
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-ns"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-team-ns", Labels: map[string]string{"team": "dev"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "qa-team-ns", Labels: map[string]string{"team": "qa"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-ns"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(namespaces, tenant)...).Build()
+	r := &DBaaSAuthzReconciler{DBaaSReconciler: &DBaaSReconciler{Client: c, Scheme: scheme}}
+	ctx := context.Background()
+	inventoryList := v1alpha1.DBaaSInventoryList{}
+
+	// without inventories, the tenant namespaces and the namespaces matching its selector are reported
+	connectionNamespaces, err := r.getConnectionNamespaces(ctx, "test-ns", inventoryList)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(connectionNamespaces).To(Equal([]string{"dev-ns", "dev-team-ns", "test-ns"}))
+
+	// the inventory settings override the tenant settings
+	inventoryList.Items = []v1alpha1.DBaaSInventory{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "qa", Namespace: "test-ns"},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ConnectionNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "qa"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test-ns"},
+			Spec:       v1alpha1.DBaaSOperatorInventorySpec{ConnectionNamespaces: []string{"other-ns"}},
+		},
+	}
+	connectionNamespaces, err = r.getConnectionNamespaces(ctx, "test-ns", inventoryList)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(connectionNamespaces).To(Equal([]string{"other-ns", "qa-team-ns", "test-ns"}))
+
+	// the inventories without settings use the tenant settings
+	inventoryList.Items = append(inventoryList.Items, v1alpha1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "test-ns"}})
+	connectionNamespaces, err = r.getConnectionNamespaces(ctx, "test-ns", inventoryList)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(connectionNamespaces).To(Equal([]string{"dev-ns", "dev-team-ns", "other-ns", "qa-team-ns", "test-ns"}))
+
+	// the wildcard allows all namespaces
+	inventoryList.Items[1].Spec.ConnectionNamespaces = []string{"*"}
+	connectionNamespaces, err = r.getConnectionNamespaces(ctx, "test-ns", inventoryList)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(connectionNamespaces).To(Equal([]string{"*"}))
+}
+
+func TestGetConnectionNSAuthz(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+	Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+	tenants := []v1alpha1.DBaaSTenant{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.DBaaSTenantSpec{
+				InventoryNamespace:   "test-ns",
+				ConnectionNamespaces: []string{"dev-ns", "missing-ns", "test-ns"},
+			},
+		},
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-ns"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "qa-team-ns", Labels: map[string]string{"team": "qa"}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespaces...).Build()
+	backend := &countingAuthzBackend{}
+	r := &DBaaSAuthzReconciler{DBaaSReconciler: &DBaaSReconciler{Client: c, Scheme: scheme}, AuthzBackend: backend}
+	ctx := context.Background()
+	nsAuthz := newConnectionNSAuthz(tenants)
+	inventories := []v1alpha1.DBaaSInventory{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "test-ns"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "default-2", Namespace: "test-ns"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "qa", Namespace: "test-ns"},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ConnectionNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "qa"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "test-ns"},
+			Spec:       v1alpha1.DBaaSOperatorInventorySpec{ConnectionNamespaces: []string{"*"}},
+		},
+	}
+
+	// the missing namespaces and the inventory namespace are not allowed, the selectors and the wildcard are resolved
+	// against the namespaces listed once
+	for i, expected := range [][]string{{"dev-ns"}, {"dev-ns"}, {"qa-team-ns"}, {"dev-ns", "qa-team-ns"}} {
+		allowedNamespaces, err := r.getAllowedConnectionNamespaces(ctx, inventories[i], nsAuthz)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sortedStrSlice(allowedNamespaces)).To(Equal(expected))
+	}
+	Expect(nsAuthz.namespaces.Items).To(HaveLen(3))
+
+	// each connection namespace is only reviewed once for all the inventories
+	for _, inventory := range inventories {
+		connectionNSAuthz, err := r.getConnectionNSAuthz(ctx, inventory, nsAuthz)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connectionNSAuthz.UsersSlice).To(Equal([]string{"user1"}))
+	}
+	Expect(backend.calls).To(Equal(int32(2)))
+}
+
+func TestConnectionNSMapFunc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	scheme := runtime.NewScheme()
+	Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+	Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+	tenants := []client.Object{
+		&v1alpha1.DBaaSTenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec:       v1alpha1.DBaaSTenantSpec{InventoryNamespace: "team-a", ConnectionNamespaces: []string{"dev-a"}},
+		},
+		&v1alpha1.DBaaSTenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+			Spec:       v1alpha1.DBaaSTenantSpec{InventoryNamespace: "team-b"},
+		},
+		&v1alpha1.DBaaSTenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-c"},
+			Spec:       v1alpha1.DBaaSTenantSpec{InventoryNamespace: "team-c", ConnectionNamespaces: []string{"*"}},
+		},
+		&v1alpha1.DBaaSTenant{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-d"},
+			Spec: v1alpha1.DBaaSTenantSpec{
+				InventoryNamespace:          "team-d",
+				ConnectionNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "d"}},
+			},
+		},
+	}
+	inventories := []client.Object{
+		// the tenant connection namespaces apply
+		&v1alpha1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "team-a"}},
+		// the inventory connection namespace selector overrides the tenant default
+		&v1alpha1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "team-b"},
+			Spec: v1alpha1.DBaaSOperatorInventorySpec{
+				ConnectionNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			},
+		},
+	}
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-b", Labels: map[string]string{"team": "b"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(append(tenants, inventories...), namespaces...)...).Build()
+	r := &DBaaSTenantAuthzReconciler{DBaaSAuthzReconciler: &DBaaSAuthzReconciler{DBaaSReconciler: &DBaaSReconciler{Client: c, Scheme: scheme}}}
+
+	requestedNamespaces := func(o client.Object) []string {
+		var names []string
+		for _, request := range r.connectionNSMapFunc(o) {
+			names = append(names, request.Namespace)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	// the inventory namespaces are only requested for their own objects, the tenants without inventories are not
+	// requested for the objects of connection namespaces
+	Expect(requestedNamespaces(&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: "team-a"}})).To(Equal([]string{"team-a"}))
+	Expect(requestedNamespaces(&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: "dev-a"}})).To(Equal([]string{"team-a"}))
+	Expect(requestedNamespaces(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "dev-b"}})).To(Equal([]string{"team-b"}))
+	Expect(requestedNamespaces(&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: "other"}})).To(BeEmpty())
+
+	// the labels of namespace events are matched against the selectors
+	Expect(requestedNamespaces(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{"team": "b"}}})).To(Equal([]string{"team-b"}))
+	Expect(requestedNamespaces(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-b"}})).To(BeEmpty())
+
+	// the tenants without inventories are requested for the namespaces matching their selector, as their status lists them
+	Expect(requestedNamespaces(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-d", Labels: map[string]string{"team": "d"}}})).To(Equal([]string{"team-d"}))
+	Expect(requestedNamespaces(&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: "dev-d"}})).To(BeEmpty())
+}
+
+func TestEnqueueCoalescedRequestsFromMapFunc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer GinkgoRecover()
+
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	eventHandler := enqueueCoalescedRequestsFromMapFunc(nsMapFunc)
+
+	// a burst of events in two namespaces is coalesced into a request per namespace, once the period has elapsed
+	for i := 0; i < 10; i++ {
+		role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("role-%d", i), Namespace: "team-a"}}
+		eventHandler.Create(event.CreateEvent{Object: role}, q)
+		eventHandler.Update(event.UpdateEvent{ObjectOld: role, ObjectNew: role}, q)
+	}
+	eventHandler.Delete(event.DeleteEvent{Object: &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: "team-b"}}}, q)
+	Expect(q.Len()).To(BeZero())
+	Eventually(q.Len, 3*authzCoalescePeriod).Should(Equal(2))
+	Consistently(q.Len, authzCoalescePeriod).Should(Equal(2))
+}
+
+var _ = Describe("DBaaSTenantAuthz controller - connection namespace RBAC", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultTenant))
+
+	connectionNS := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "authz-connection-ns",
+		},
+	}
+	inventory := &v1alpha1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-authz-inventory",
+			Namespace: testNamespace,
+		},
+		Spec: v1alpha1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1alpha1.NamespacedName{
+				Name: testProviderName,
+			},
+			DBaaSInventorySpec: v1alpha1.DBaaSInventorySpec{
+				CredentialsRef: &v1alpha1.NamespacedName{
+					Name:      testSecret.Name,
+					Namespace: testNamespace,
+				},
+			},
+			ConnectionNamespaces: []string{connectionNS.Name},
+		},
+	}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "connection-editor",
+			Namespace: connectionNS.Name,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{v1alpha1.GroupVersion.Group},
+				Resources: []string{"dbaasconnections"},
+				Verbs:     []string{"create"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "connection-editors",
+			Namespace: connectionNS.Name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.UserKind,
+				Name:     "authz-developer",
+			},
+		},
+	}
+	BeforeEach(assertResourceCreationIfNotExists(&connectionNS))
+	BeforeEach(assertResourceCreation(inventory))
+	AfterEach(assertResourceDeletion(inventory))
+
+	inventoryViewerHasDeveloper := func() bool {
+		viewers := &rbacv1.RoleBinding{}
+		if err := dRec.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dbaas-" + inventory.Name + "-inventory-viewers"}, viewers); err != nil {
+			return false
+		}
+		for _, subject := range viewers.Subjects {
+			if subject.Kind == rbacv1.UserKind && subject.Name == "authz-developer" {
+				return true
+			}
+		}
+		return false
+	}
+
+	It("should sync the inventory viewers with the roles and role bindings of the connection namespaces", func() {
+		By("creating a role and role binding allowing a developer to create connections")
+		Expect(dRec.Create(ctx, role)).Should(Succeed())
+		Expect(dRec.Create(ctx, roleBinding)).Should(Succeed())
+		Eventually(inventoryViewerHasDeveloper, timeout).Should(BeTrue())
+
+		By("removing the rule of the role")
+		Expect(dRec.Get(ctx, client.ObjectKeyFromObject(role), role)).Should(Succeed())
+		role.Rules[0].Verbs = []string{"get"}
+		Expect(dRec.Update(ctx, role)).Should(Succeed())
+		Eventually(inventoryViewerHasDeveloper, timeout).Should(BeFalse())
+
+		By("restoring the rule of the role")
+		Expect(dRec.Get(ctx, client.ObjectKeyFromObject(role), role)).Should(Succeed())
+		role.Rules[0].Verbs = []string{"create"}
+		Expect(dRec.Update(ctx, role)).Should(Succeed())
+		Eventually(inventoryViewerHasDeveloper, timeout).Should(BeTrue())
+
+		By("deleting the role binding")
+		Expect(dRec.Delete(ctx, roleBinding)).Should(Succeed())
+		Eventually(inventoryViewerHasDeveloper, timeout).Should(BeFalse())
+		Expect(dRec.Delete(ctx, role)).Should(Succeed())
+	})
+})
@@ -0,0 +1,147 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// DBaaSTenantQuotaReconciler reports the usage of a DBaaSTenant's quotas
+type DBaaSTenantQuotaReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
+func (r *DBaaSTenantQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var tenant v1alpha1.DBaaSTenant
+	if err := r.Get(ctx, req.NamespacedName, &tenant); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, no requeue
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Tenant for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	usage, err := r.getTenantUsage(ctx, tenant.Spec.InventoryNamespace)
+	if err != nil {
+		logger.Error(err, "Error calculating DBaaS Tenant usage")
+		return ctrl.Result{}, err
+	}
+
+	if !reflect.DeepEqual(tenant.Status.Usage, usage) {
+		tenant.Status.Usage = usage
+		if err := r.Client.Status().Update(ctx, &tenant); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Tenant resource modified, retry syncing status", "DBaaS Tenant", tenant.Name)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error updating the DBaaS Tenant resource status", "DBaaS Tenant", tenant.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSTenantQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dbaastenantquota").
+		For(&v1alpha1.DBaaSTenant{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// instance and connection events update the usage of the tenant owning the referenced inventory
+		Watches(
+			&source.Kind{Type: &v1alpha1.DBaaSInstance{}},
+			handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+				instance := o.(*v1alpha1.DBaaSInstance)
+				return r.tenantRequests(v1alpha1.InventoryRefNamespace(instance.Spec.InventoryRef, instance.Namespace))
+			}),
+		).
+		Watches(
+			&source.Kind{Type: &v1alpha1.DBaaSConnection{}},
+			handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+				connection := o.(*v1alpha1.DBaaSConnection)
+				return r.tenantRequests(v1alpha1.InventoryRefNamespace(connection.Spec.InventoryRef, connection.Namespace))
+			}),
+		).
+		Complete(r)
+}
+
+// returns a request for each tenant managing the inventory namespace
+func (r *DBaaSTenantQuotaReconciler) tenantRequests(inventoryNamespace string) []reconcile.Request {
+	tenantList, err := r.tenantListByInventoryNS(context.Background(), inventoryNamespace)
+	if err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, tenant := range tenantList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tenant.Name}})
+	}
+	return requests
+}
+
+// counts the instances and connections referencing inventories in the tenant namespace
+func (r *DBaaSTenantQuotaReconciler) getTenantUsage(ctx context.Context, inventoryNamespace string) (*v1alpha1.DBaaSTenantUsage, error) {
+	usage := &v1alpha1.DBaaSTenantUsage{}
+
+	var instanceList v1alpha1.DBaaSInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, err
+	}
+	for _, instance := range instanceList.Items {
+		if v1alpha1.InventoryRefNamespace(instance.Spec.InventoryRef, instance.Namespace) == inventoryNamespace {
+			usage.Instances++
+		}
+	}
+
+	var connectionList v1alpha1.DBaaSConnectionList
+	if err := r.List(ctx, &connectionList); err != nil {
+		return nil, err
+	}
+	for _, connection := range connectionList.Items {
+		if v1alpha1.InventoryRefNamespace(connection.Spec.InventoryRef, connection.Namespace) == inventoryNamespace {
+			if usage.ConnectionsPerNamespace == nil {
+				usage.ConnectionsPerNamespace = map[string]int32{}
+			}
+			usage.ConnectionsPerNamespace[connection.Namespace]++
+		}
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+var _ = Describe("DBaaSTenantQuota controller", func() {
+	Context("after creating a DBaaSTenant with quotas", func() {
+		inventoryNamespace := "test-quota-inventory-ns"
+		tenant := &v1alpha1.DBaaSTenant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-quota-tenant",
+			},
+			Spec: v1alpha1.DBaaSTenantSpec{
+				InventoryNamespace: inventoryNamespace,
+				Quotas: &v1alpha1.DBaaSTenantQuotas{
+					MaxInstances:               pointer.Int32(5),
+					MaxConnectionsPerNamespace: pointer.Int32(5),
+				},
+			},
+		}
+		inventoryRef := v1alpha1.NamespacedName{
+			Name:      "test-quota-inventory",
+			Namespace: inventoryNamespace,
+		}
+		instance := &v1alpha1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-quota-instance",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSOperatorInstanceSpec{
+				DBaaSInstanceSpec: v1alpha1.DBaaSInstanceSpec{
+					InventoryRef: inventoryRef,
+					Name:         "test-quota-instance",
+				},
+			},
+		}
+		connection := &v1alpha1.DBaaSConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-quota-connection",
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.DBaaSConnectionSpec{
+				InventoryRef: inventoryRef,
+				InstanceID:   "test-instance-id",
+			},
+		}
+
+		BeforeEach(assertResourceCreation(tenant))
+		BeforeEach(assertResourceCreation(instance))
+		BeforeEach(assertResourceCreation(connection))
+		AfterEach(assertResourceDeletion(connection))
+		AfterEach(assertResourceDeletion(instance))
+		AfterEach(assertResourceDeletion(tenant))
+
+		It("should report the usage in the DBaaSTenant status", func() {
+			Eventually(func() *v1alpha1.DBaaSTenantUsage {
+				updatedTenant := &v1alpha1.DBaaSTenant{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(tenant), updatedTenant); err != nil {
+					return nil
+				}
+				return updatedTenant.Status.Usage
+			}, timeout).Should(Equal(&v1alpha1.DBaaSTenantUsage{
+				Instances:               1,
+				ConnectionsPerNamespace: map[string]int32{testNamespace: 1},
+			}))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package reconcilers
+
+import (
+	"k8s.io/client-go/discovery"
+)
+
+// ClusterCapabilities reports which of the APIs the platform components depend on are served by the cluster
+type ClusterCapabilities struct {
+	// OLM subscriptions, to install the provider operators
+	OLM bool
+	// console plugins and the console operator config, to install the console plugins
+	ConsolePlugins bool
+	// console quick starts
+	ConsoleQuickStarts bool
+}
+
+// DiscoverCapabilities detects the capabilities of the cluster through the discovery API
+func DiscoverCapabilities(client discovery.DiscoveryInterface) (ClusterCapabilities, error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return ClusterCapabilities{}, err
+	}
+	served := map[string]bool{}
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			served[version.GroupVersion] = true
+		}
+	}
+
+	hasResource := func(groupVersion string, name string) (bool, error) {
+		if !served[groupVersion] {
+			return false, nil
+		}
+		resources, err := client.ServerResourcesForGroupVersion(groupVersion)
+		if err != nil {
+			return false, err
+		}
+		for _, resource := range resources.APIResources {
+			if resource.Name == name {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var capabilities ClusterCapabilities
+	if capabilities.OLM, err = hasResource("operators.coreos.com/v1alpha1", "subscriptions"); err != nil {
+		return capabilities, err
+	}
+	consolePlugins, err := hasResource("console.openshift.io/v1alpha1", "consoleplugins")
+	if err != nil {
+		return capabilities, err
+	}
+	consoles, err := hasResource("operator.openshift.io/v1", "consoles")
+	if err != nil {
+		return capabilities, err
+	}
+	capabilities.ConsolePlugins = consolePlugins && consoles
+	if capabilities.ConsoleQuickStarts, err = hasResource("console.openshift.io/v1", "consolequickstarts"); err != nil {
+		return capabilities, err
+	}
+	return capabilities, nil
+}
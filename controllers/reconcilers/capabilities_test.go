@@ -0,0 +1,55 @@
+package reconcilers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_DiscoverCapabilities(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		want      ClusterCapabilities
+	}{
+		{
+			name: "OpenShift cluster",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "operators.coreos.com/v1alpha1", APIResources: []metav1.APIResource{{Name: "subscriptions"}}},
+				{GroupVersion: "console.openshift.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "consoleplugins"}}},
+				{GroupVersion: "console.openshift.io/v1", APIResources: []metav1.APIResource{{Name: "consolequickstarts"}}},
+				{GroupVersion: "operator.openshift.io/v1", APIResources: []metav1.APIResource{{Name: "consoles"}}},
+			},
+			want: ClusterCapabilities{OLM: true, ConsolePlugins: true, ConsoleQuickStarts: true},
+		},
+		{
+			name: "Kubernetes cluster with OLM",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "configmaps"}}},
+				{GroupVersion: "operators.coreos.com/v1alpha1", APIResources: []metav1.APIResource{{Name: "subscriptions"}}},
+			},
+			want: ClusterCapabilities{OLM: true},
+		},
+		{
+			name: "Kubernetes cluster",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "configmaps"}}},
+			},
+			want: ClusterCapabilities{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: test.resources}}
+			got, err := DiscoverCapabilities(client)
+			if err != nil {
+				t.Fatalf("DiscoverCapabilities() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("DiscoverCapabilities() got = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
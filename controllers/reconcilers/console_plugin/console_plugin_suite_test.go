@@ -0,0 +1,13 @@
+package console_plugin
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConsolePlugin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Console Plugin Suite")
+}
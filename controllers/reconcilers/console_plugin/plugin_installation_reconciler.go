@@ -2,10 +2,12 @@ package console_plugin
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	appv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,21 +28,24 @@ import (
 const (
 	serviceCertPrefix = "serve-cert-"
 	consolePort       = 9001
+	defaultReplicas   = 3
 )
 
 type Reconciler struct {
-	client client.Client
-	logger logr.Logger
-	scheme *runtime.Scheme
-	config v1alpha1.PlatformConfig
+	client   client.Client
+	logger   logr.Logger
+	scheme   *runtime.Scheme
+	platform v1alpha1.PlatformsName
+	config   v1alpha1.PlatformConfig
 }
 
-func NewReconciler(client client.Client, scheme *runtime.Scheme, logger logr.Logger, config v1alpha1.PlatformConfig) reconcilers.PlatformReconciler {
+func NewReconciler(client client.Client, scheme *runtime.Scheme, logger logr.Logger, platform v1alpha1.PlatformsName, config v1alpha1.PlatformConfig) reconcilers.PlatformReconciler {
 	return &Reconciler{
-		client: client,
-		scheme: scheme,
-		logger: logger,
-		config: config,
+		client:   client,
+		scheme:   scheme,
+		logger:   logger,
+		platform: platform,
+		config:   config,
 	}
 }
 func (r *Reconciler) Reconcile(ctx context.Context, cr *v1alpha1.DBaaSPlatform, status2 *v1alpha1.DBaaSPlatformStatus) (v1alpha1.PlatformsInstlnStatus, error) {
@@ -52,6 +57,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, cr *v1alpha1.DBaaSPlatform,
 	if status != v1alpha1.ResultSuccess {
 		return status, err
 	}
+	status, err = r.reconcilePodDisruptionBudget(cr, ctx)
+	if status != v1alpha1.ResultSuccess {
+		return status, err
+	}
 
 	// create Console Plugin CR resource that includes Console Plugin service name.
 	status, err = r.createConsolePluginCR(cr, ctx)
@@ -67,16 +76,45 @@ func (r *Reconciler) Reconcile(ctx context.Context, cr *v1alpha1.DBaaSPlatform,
 	return v1alpha1.ResultSuccess, nil
 }
 
+// CheckHealth reports an unhealthy console plugin deployment, and a console plugin missing or removed from the console
+func (r *Reconciler) CheckHealth(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (string, error) {
+	if reason, err := reconcilers.CheckDeploymentHealth(ctx, r.client, cr.Namespace, r.config.Name); reason != "" || err != nil {
+		return reason, err
+	}
+
+	plugin := r.getConsolePlugin()
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(plugin), plugin); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("the console plugin %s is missing", plugin.Name), nil
+		}
+		return "", err
+	}
+
+	console := r.getOperatorConsole()
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(console), console); err != nil {
+		return "", err
+	}
+	if _, add := r.addPlugin(console.Spec.Plugins); add {
+		return fmt.Sprintf("the console plugin %s is not enabled in the console", plugin.Name), nil
+	}
+	return "", nil
+}
+
 func (r *Reconciler) Cleanup(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (v1alpha1.PlatformsInstlnStatus, error) {
 	console := r.getOperatorConsole()
 	err := r.client.Get(ctx, client.ObjectKeyFromObject(console), console)
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return v1alpha1.ResultFailed, err
 	}
-	console.Spec.Plugins = r.removePlugin(console.Spec.Plugins)
-	err = r.client.Update(ctx, console)
-	if err != nil {
-		return v1alpha1.ResultFailed, err
+	if plugins, removed := r.removePlugin(console.Spec.Plugins); err == nil && removed {
+		console.Spec.Plugins = plugins
+		err = r.client.Update(ctx, console)
+		if err != nil {
+			if errors.IsConflict(err) {
+				return v1alpha1.ResultInProgress, nil
+			}
+			return v1alpha1.ResultFailed, err
+		}
 	}
 
 	plugin := r.getConsolePlugin()
@@ -85,6 +123,12 @@ func (r *Reconciler) Cleanup(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (v
 		return v1alpha1.ResultFailed, err
 	}
 
+	podDisruptionBudget := r.getPodDisruptionBudget(cr)
+	err = r.client.Delete(ctx, podDisruptionBudget)
+	if err != nil && !errors.IsNotFound(err) {
+		return v1alpha1.ResultFailed, err
+	}
+
 	deployment := r.getDeployment(cr)
 	err = r.client.Delete(ctx, deployment)
 	if err != nil && !errors.IsNotFound(err) {
@@ -153,7 +197,7 @@ func (r *Reconciler) reconcileDeployment(cr *v1alpha1.DBaaSPlatform, ctx context
 			"app.kubernetes.io/part-of":          r.config.Name,
 			"app.openshift.io/runtime-namespace": cr.Namespace,
 		}
-		replicas := int32(3)
+		replicas := r.getReplicas(cr)
 		defaultMode := int32(420)
 		ptrTrue := true
 		ptrFalse := false
@@ -182,7 +226,7 @@ func (r *Reconciler) reconcileDeployment(cr *v1alpha1.DBaaSPlatform, ctx context
 						Protocol:      v1.ProtocolTCP,
 					},
 				},
-				ImagePullPolicy: v1.PullAlways,
+				ImagePullPolicy: reconcilers.GetImagePullPolicy(cr, v1.PullAlways),
 				Args: []string{
 					"--ssl",
 					"--cert=/var/serving-cert/tls.crt",
@@ -213,9 +257,22 @@ func (r *Reconciler) reconcileDeployment(cr *v1alpha1.DBaaSPlatform, ctx context
 				},
 			},
 		}
+		if plugin := cr.Spec.GetConsolePlugin(r.platform); plugin != nil && plugin.Resources != nil {
+			deployment.Spec.Template.Spec.Containers[0].Resources = *plugin.Resources
+		}
 		deployment.Spec.Template.Spec.SecurityContext = &v1.PodSecurityContext{
 			RunAsNonRoot: &ptrTrue,
 		}
+		deployment.Spec.Template.Spec.NodeSelector = nil
+		deployment.Spec.Template.Spec.Tolerations = nil
+		deployment.Spec.Template.Spec.Affinity = nil
+		deployment.Spec.Template.Spec.PriorityClassName = ""
+		if plugin := cr.Spec.GetConsolePlugin(r.platform); plugin != nil {
+			deployment.Spec.Template.Spec.NodeSelector = plugin.NodeSelector
+			deployment.Spec.Template.Spec.Tolerations = plugin.Tolerations
+			deployment.Spec.Template.Spec.Affinity = plugin.Affinity
+			deployment.Spec.Template.Spec.PriorityClassName = plugin.PriorityClassName
+		}
 		deployment.Spec.Template.Spec.Volumes = []v1.Volume{
 			{
 				Name: serviceCertPrefix + r.config.Name,
@@ -258,6 +315,39 @@ func (r *Reconciler) reconcileDeployment(cr *v1alpha1.DBaaSPlatform, ctx context
 	return v1alpha1.ResultInProgress, nil
 }
 
+// reconcilePodDisruptionBudget keeps one console plugin replica available during voluntary disruptions,
+// if there are multiple replicas
+func (r *Reconciler) reconcilePodDisruptionBudget(cr *v1alpha1.DBaaSPlatform, ctx context.Context) (v1alpha1.PlatformsInstlnStatus, error) {
+	podDisruptionBudget := r.getPodDisruptionBudget(cr)
+	if r.getReplicas(cr) < 2 {
+		if err := r.client.Delete(ctx, podDisruptionBudget); err != nil && !errors.IsNotFound(err) {
+			return v1alpha1.ResultFailed, err
+		}
+		return v1alpha1.ResultSuccess, nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.client, podDisruptionBudget, func() error {
+		if err := ctrl.SetControllerReference(cr, podDisruptionBudget, r.scheme); err != nil {
+			return err
+		}
+		minAvailable := intstr.FromInt(1)
+		podDisruptionBudget.Spec.MinAvailable = &minAvailable
+		podDisruptionBudget.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": r.config.Name,
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.IsConflict(err) {
+			return v1alpha1.ResultInProgress, nil
+		}
+		return v1alpha1.ResultFailed, err
+	}
+	return v1alpha1.ResultSuccess, nil
+}
+
 func (r *Reconciler) createConsolePluginCR(cr *v1alpha1.DBaaSPlatform, ctx context.Context) (v1alpha1.PlatformsInstlnStatus, error) {
 	plugin := r.getConsolePlugin()
 	_, err := controllerutil.CreateOrUpdate(ctx, r.client, plugin, func() error {
@@ -330,6 +420,22 @@ func (r *Reconciler) getDeployment(cr *v1alpha1.DBaaSPlatform) *appv1.Deployment
 	}
 }
 
+func (r *Reconciler) getPodDisruptionBudget(cr *v1alpha1.DBaaSPlatform) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.config.Name,
+			Namespace: cr.Namespace,
+		},
+	}
+}
+
+func (r *Reconciler) getReplicas(cr *v1alpha1.DBaaSPlatform) int32 {
+	if plugin := cr.Spec.GetConsolePlugin(r.platform); plugin != nil && plugin.Replicas != nil {
+		return *plugin.Replicas
+	}
+	return defaultReplicas
+}
+
 func (r *Reconciler) getConsolePlugin() *consolev1alpha1.ConsolePlugin {
 	return &consolev1alpha1.ConsolePlugin{
 		ObjectMeta: metav1.ObjectMeta{
@@ -356,12 +462,12 @@ func (r *Reconciler) addPlugin(plugins []string) ([]string, bool) {
 	return append(plugins, r.config.Name), true
 }
 
-func (r *Reconciler) removePlugin(plugins []string) []string {
+func (r *Reconciler) removePlugin(plugins []string) ([]string, bool) {
 	for i, p := range plugins {
 		if p == r.config.Name {
-			return append(plugins[:i], plugins[i+1:]...)
+			return append(plugins[:i], plugins[i+1:]...), true
 		}
 	}
 
-	return plugins
+	return plugins, false
 }
@@ -0,0 +1,151 @@
+package console_plugin
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	consolev1alpha1 "github.com/openshift/api/console/v1alpha1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+)
+
+var _ = Describe("Console plugin installation reconciler", func() {
+	const namespace = "test-namespace"
+	ctx := context.Background()
+	platform := v1alpha1.DBaaSDynamicPluginInstallation
+	config := reconcilers.InstallationPlatforms[platform]
+
+	var c client.Client
+	var cr *v1alpha1.DBaaSPlatform
+	var r *Reconciler
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(consolev1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(operatorv1.AddToScheme(scheme)).Should(Succeed())
+		cr = &v1alpha1.DBaaSPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "dbaas-platform", Namespace: namespace, UID: "platform-uid"},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build()
+		r = NewReconciler(c, scheme, ctrl.Log, platform, config).(*Reconciler)
+	})
+
+	reconcile := func() (*appv1.Deployment, *policyv1.PodDisruptionBudget) {
+		Expect(r.reconcileDeployment(cr, ctx)).Should(Equal(v1alpha1.ResultSuccess))
+		Expect(r.reconcilePodDisruptionBudget(cr, ctx)).Should(Equal(v1alpha1.ResultSuccess))
+		deployment := r.getDeployment(cr)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+		podDisruptionBudget := r.getPodDisruptionBudget(cr)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(podDisruptionBudget), podDisruptionBudget); err != nil {
+			Expect(errors.IsNotFound(err)).Should(BeTrue())
+			return deployment, nil
+		}
+		return deployment, podDisruptionBudget
+	}
+
+	It("should deploy 3 replicas by default", func() {
+		deployment, podDisruptionBudget := reconcile()
+		Expect(*deployment.Spec.Replicas).Should(Equal(int32(3)))
+		Expect(deployment.Spec.Template.Spec.NodeSelector).Should(BeNil())
+		Expect(deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy).Should(Equal(v1.PullAlways))
+		Expect(podDisruptionBudget).ShouldNot(BeNil())
+		Expect(podDisruptionBudget.Spec.MinAvailable.IntValue()).Should(Equal(1))
+		Expect(podDisruptionBudget.Spec.Selector.MatchLabels).Should(Equal(map[string]string{"app": config.Name}))
+	})
+
+	It("should apply the console plugin deployment settings", func() {
+		reconcile()
+
+		resources := v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("10m")},
+		}
+		tolerations := []v1.Toleration{
+			{Key: "node-role.kubernetes.io/master", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+		}
+		cr.Spec.ConsolePlugins = []v1alpha1.DBaaSPlatformConsolePlugin{
+			{
+				Name:              platform,
+				Replicas:          pointer.Int32(1),
+				Resources:         &resources,
+				NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:       tolerations,
+				PriorityClassName: "system-cluster-critical",
+			},
+			{
+				Name:     v1alpha1.ConsoleTelemetryPluginInstallation,
+				Replicas: pointer.Int32(2),
+			},
+		}
+		deployment, podDisruptionBudget := reconcile()
+		Expect(*deployment.Spec.Replicas).Should(Equal(int32(1)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Resources).Should(Equal(resources))
+		Expect(deployment.Spec.Template.Spec.NodeSelector).Should(Equal(map[string]string{"node-role.kubernetes.io/infra": ""}))
+		Expect(deployment.Spec.Template.Spec.Tolerations).Should(Equal(tolerations))
+		Expect(deployment.Spec.Template.Spec.PriorityClassName).Should(Equal("system-cluster-critical"))
+		Expect(podDisruptionBudget).Should(BeNil())
+
+		cr.Spec.ConsolePlugins = nil
+		deployment, podDisruptionBudget = reconcile()
+		Expect(*deployment.Spec.Replicas).Should(Equal(int32(3)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Resources).Should(Equal(v1.ResourceRequirements{}))
+		Expect(deployment.Spec.Template.Spec.NodeSelector).Should(BeNil())
+		Expect(deployment.Spec.Template.Spec.PriorityClassName).Should(BeEmpty())
+		Expect(podDisruptionBudget).ShouldNot(BeNil())
+	})
+
+	It("should remove the console plugin from the console on cleanup", func() {
+		console := r.getOperatorConsole()
+		console.Spec.Plugins = []string{config.Name, "other-plugin"}
+		Expect(c.Create(ctx, console)).Should(Succeed())
+		reconcile()
+		Expect(r.createConsolePluginCR(cr, ctx)).Should(Equal(v1alpha1.ResultSuccess))
+
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1alpha1.ResultSuccess))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(console), console)).Should(Succeed())
+		Expect(console.Spec.Plugins).Should(Equal([]string{"other-plugin"}))
+		for _, obj := range []client.Object{r.getConsolePlugin(), r.getDeployment(cr), r.getService(cr), r.getPodDisruptionBudget(cr)} {
+			err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+			Expect(errors.IsNotFound(err)).Should(BeTrue())
+		}
+
+		// disabled console plugins are cleaned up on every reconcile
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1alpha1.ResultSuccess))
+	})
+
+	It("should report a console plugin removed from the console", func() {
+		console := r.getOperatorConsole()
+		console.Spec.Plugins = []string{config.Name}
+		Expect(c.Create(ctx, console)).Should(Succeed())
+		deployment, _ := reconcile()
+		deployment.Status.ReadyReplicas = 3
+		Expect(c.Update(ctx, deployment)).Should(Succeed())
+		Expect(r.CheckHealth(ctx, cr)).Should(Equal("the console plugin " + config.Name + " is missing"))
+
+		Expect(r.createConsolePluginCR(cr, ctx)).Should(Equal(v1alpha1.ResultSuccess))
+		Expect(r.CheckHealth(ctx, cr)).Should(BeEmpty())
+
+		console.Spec.Plugins = []string{"other-plugin"}
+		Expect(c.Update(ctx, console)).Should(Succeed())
+		Expect(r.CheckHealth(ctx, cr)).Should(Equal("the console plugin " + config.Name + " is not enabled in the console"))
+		Expect(r.enableConsolePluginConfig(ctx)).Should(Equal(v1alpha1.ResultInProgress))
+		Expect(r.CheckHealth(ctx, cr)).Should(BeEmpty())
+	})
+})
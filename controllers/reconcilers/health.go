@@ -0,0 +1,49 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const crashLoopBackOff = "CrashLoopBackOff"
+
+// CheckDeploymentHealth returns why a deployment is degraded: it is missing, some of its pods are crash looping, or it
+// has no ready replicas; or an empty string if it is healthy
+func CheckDeploymentHealth(ctx context.Context, c client.Reader, namespace string, name string) (string, error) {
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("the deployment %s is missing", name), nil
+		}
+		return "", err
+	}
+
+	if deployment.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return "", err
+		}
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return "", err
+		}
+		for _, pod := range pods.Items {
+			for _, containerStatus := range pod.Status.ContainerStatuses {
+				if containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason == crashLoopBackOff {
+					return fmt.Sprintf("the pod %s of the deployment %s is crash looping", pod.Name, name), nil
+				}
+			}
+		}
+	}
+
+	if deployment.Status.ReadyReplicas == 0 {
+		return fmt.Sprintf("the deployment %s has no ready replicas", name), nil
+	}
+	return "", nil
+}
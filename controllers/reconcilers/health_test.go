@@ -0,0 +1,74 @@
+package reconcilers
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_CheckDeploymentHealth(t *testing.T) {
+	const namespace = "test-namespace"
+	deployment := func(readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "operator", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "operator"}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+		}
+	}
+	pod := func(name string, app string, waitingReason string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "manager", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		objs []client.Object
+		want string
+	}{
+		{
+			name: "healthy deployment",
+			objs: []client.Object{deployment(1), pod("operator-1", "operator", "ContainerCreating"), pod("other-1", "other", crashLoopBackOff)},
+			want: "",
+		},
+		{
+			name: "missing deployment",
+			want: "the deployment operator is missing",
+		},
+		{
+			name: "crash looping pod",
+			objs: []client.Object{deployment(1), pod("operator-1", "operator", crashLoopBackOff)},
+			want: "the pod operator-1 of the deployment operator is crash looping",
+		},
+		{
+			name: "no ready replicas",
+			objs: []client.Object{deployment(0)},
+			want: "the deployment operator has no ready replicas",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(tt.objs...).Build()
+			got, err := CheckDeploymentHealth(context.Background(), c, namespace, "operator")
+			if err != nil {
+				t.Fatalf("CheckDeploymentHealth() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CheckDeploymentHealth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package reconcilers
+
+import (
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+
+	alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+// GetImage resolves an image deployed by the operator: the DBaaSPlatform override first, then the RELATED_IMAGE_*
+// environment variable set by OLM from the related images of the operator CSV, and finally the default image
+func GetImage(override string, relatedImageEnv string, defaultImage string) string {
+	if override != "" {
+		return override
+	}
+	if relatedImageEnv != "" {
+		if image, found := os.LookupEnv(relatedImageEnv); found && image != "" {
+			return image
+		}
+	}
+	return defaultImage
+}
+
+// GetImagePullPolicy returns the image pull policy set by the DBaaSPlatform, or else the default policy
+func GetImagePullPolicy(cr *alpha1.DBaaSPlatform, defaultPolicy corev1.PullPolicy) corev1.PullPolicy {
+	if cr != nil && cr.Spec.ImagePullPolicy != "" {
+		return cr.Spec.ImagePullPolicy
+	}
+	return defaultPolicy
+}
@@ -0,0 +1,58 @@
+package reconcilers
+
+import (
+	"os"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+func Test_GetImage(t *testing.T) {
+	os.Setenv(MONGODB_ATLAS_CATALOG_ENV, "mirror.example.com/mongodb-atlas-catalog@sha256:1234")
+	defer os.Unsetenv(MONGODB_ATLAS_CATALOG_ENV)
+
+	tests := []struct {
+		name     string
+		override string
+		env      string
+		want     string
+	}{
+		{
+			name: "default image",
+			env:  CRUNCHY_BRIDGE_CATALOG_ENV,
+			want: CRUNCHY_BRIDGE_CATALOG_IMG,
+		},
+		{
+			name: "related image",
+			env:  MONGODB_ATLAS_CATALOG_ENV,
+			want: "mirror.example.com/mongodb-atlas-catalog@sha256:1234",
+		},
+		{
+			name:     "overridden image",
+			override: "mirror.example.com/mongodb-atlas-catalog:0.2.1",
+			env:      MONGODB_ATLAS_CATALOG_ENV,
+			want:     "mirror.example.com/mongodb-atlas-catalog:0.2.1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			images := &alpha1.DBaaSPlatformImages{MongoDBAtlasCatalog: test.override}
+			got := GetImage(images.GetImage(alpha1.MongoDBAtlasInstallation), test.env, CRUNCHY_BRIDGE_CATALOG_IMG)
+			if got != test.want {
+				t.Errorf("GetImage() got = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_GetImagePullPolicy(t *testing.T) {
+	if got := GetImagePullPolicy(nil, corev1.PullAlways); got != corev1.PullAlways {
+		t.Errorf("GetImagePullPolicy() got = %v, want %v", got, corev1.PullAlways)
+	}
+	cr := &alpha1.DBaaSPlatform{Spec: alpha1.DBaaSPlatformSpec{ImagePullPolicy: corev1.PullIfNotPresent}}
+	if got := GetImagePullPolicy(cr, corev1.PullAlways); got != corev1.PullIfNotPresent {
+		t.Errorf("GetImagePullPolicy() got = %v, want %v", got, corev1.PullIfNotPresent)
+	}
+}
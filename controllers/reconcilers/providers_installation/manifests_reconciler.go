@@ -0,0 +1,490 @@
+package providers_installation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+)
+
+const customResourceDefinitionKind = "CustomResourceDefinition"
+
+// the kinds installed from the manifests of a provider operator, besides the custom resources of its CRDs
+var manifestKinds = map[schema.GroupKind]bool{
+	{Group: "apiextensions.k8s.io", Kind: customResourceDefinitionKind}: true,
+	{Group: "", Kind: "ServiceAccount"}:                                 true,
+	{Group: "", Kind: "ConfigMap"}:                                      true,
+	{Group: "", Kind: "Service"}:                                        true,
+	{Group: appsv1.GroupName, Kind: "Deployment"}:                       true,
+	{Group: rbacv1.GroupName, Kind: "Role"}:                             true,
+	{Group: rbacv1.GroupName, Kind: "RoleBinding"}:                      true,
+	{Group: rbacv1.GroupName, Kind: "ClusterRole"}:                      true,
+	{Group: rbacv1.GroupName, Kind: "ClusterRoleBinding"}:               true,
+}
+
+// ManifestsReconciler installs a provider operator from the manifests of a ConfigMap, on clusters without OLM
+type ManifestsReconciler struct {
+	client   client.Client
+	logger   logr.Logger
+	scheme   *runtime.Scheme
+	mapper   meta.RESTMapper
+	platform v1.PlatformsName
+	config   v1.PlatformConfig
+}
+
+func NewManifestsReconciler(client client.Client, scheme *runtime.Scheme, mapper meta.RESTMapper, logger logr.Logger, platform v1.PlatformsName, config v1.PlatformConfig) reconcilers.PlatformReconciler {
+	return &ManifestsReconciler{
+		client:   client,
+		scheme:   scheme,
+		mapper:   mapper,
+		logger:   logger,
+		platform: platform,
+		config:   config,
+	}
+}
+
+func (r *ManifestsReconciler) Reconcile(ctx context.Context, cr *v1.DBaaSPlatform, status *v1.DBaaSPlatformStatus) (v1.PlatformsInstlnStatus, error) {
+	objs, csvName, err := r.getManifests(ctx, cr)
+	if err != nil {
+		return v1.ResultFailed, err
+	}
+
+	config := getSubscriptionConfig(cr, r.platform)
+	for _, obj := range objs {
+		if obj.GetKind() == "Deployment" && config != nil {
+			if err := applySubscriptionConfig(obj, config); err != nil {
+				return v1.ResultFailed, err
+			}
+		}
+		if err := r.applyObject(ctx, obj); err != nil {
+			if meta.IsNoMatchError(err) {
+				// the CRDs of the manifests are not established yet
+				return v1.ResultInProgress, nil
+			}
+			return v1.ResultFailed, err
+		}
+	}
+
+	for _, obj := range objs {
+		if obj.GetKind() != "Deployment" {
+			continue
+		}
+		deployment := &appsv1.Deployment{}
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), deployment); err != nil {
+			if errors.IsNotFound(err) {
+				return v1.ResultInProgress, nil
+			}
+			return v1.ResultFailed, err
+		}
+		if deployment.Status.ReadyReplicas == 0 {
+			return v1.ResultInProgress, nil
+		}
+	}
+
+	if status != nil {
+		status.SetProviderStatus(v1.DBaaSPlatformProviderStatus{
+			Name:         r.platform,
+			InstalledCSV: csvName,
+		})
+	}
+	return v1.ResultSuccess, nil
+}
+
+// CheckHealth reports the unhealthy deployments of the manifests
+func (r *ManifestsReconciler) CheckHealth(ctx context.Context, cr *v1.DBaaSPlatform) (string, error) {
+	objs, _, err := r.getManifests(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	for _, obj := range objs {
+		if obj.GetKind() != "Deployment" {
+			continue
+		}
+		if reason, err := reconcilers.CheckDeploymentHealth(ctx, r.client, obj.GetNamespace(), obj.GetName()); reason != "" || err != nil {
+			return reason, err
+		}
+	}
+	return "", nil
+}
+
+// Cleanup deletes the objects of the manifests, except the CRDs, which OLM does not delete either, unless the
+// platforms are purged
+func (r *ManifestsReconciler) Cleanup(ctx context.Context, cr *v1.DBaaSPlatform) (v1.PlatformsInstlnStatus, error) {
+	objs, _, err := r.getManifests(ctx, cr)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return v1.ResultSuccess, nil
+		}
+		return v1.ResultFailed, err
+	}
+	for i := len(objs) - 1; i >= 0; i-- {
+		if objs[i].GetKind() == customResourceDefinitionKind && !cr.Spec.IsPurged() {
+			continue
+		}
+		if err := r.client.Delete(ctx, objs[i]); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return v1.ResultFailed, err
+		}
+	}
+	return v1.ResultSuccess, nil
+}
+
+// GetProviderKinds returns the kinds of the CRDs of the manifests
+func (r *ManifestsReconciler) GetProviderKinds(ctx context.Context, cr *v1.DBaaSPlatform) ([]string, error) {
+	objs, _, err := r.getManifests(ctx, cr)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var kinds []string
+	for _, obj := range objs {
+		if obj.GetKind() != customResourceDefinitionKind {
+			continue
+		}
+		kind, _, err := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+		if err != nil {
+			return nil, err
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// getManifests returns the objects of the provider manifests, with the install strategy of a CSV expanded into
+// the objects OLM would create, and the name of the CSV if there is one
+func (r *ManifestsReconciler) getManifests(ctx context.Context, cr *v1.DBaaSPlatform) ([]*unstructured.Unstructured, string, error) {
+	provider := cr.Spec.GetProvider(r.platform)
+	if provider == nil || provider.ManifestsRef == nil {
+		return nil, "", fmt.Errorf("provider %s has no manifests to install without OLM", r.platform)
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: cr.Namespace, Name: provider.ManifestsRef.Name}, configMap); err != nil {
+		return nil, "", err
+	}
+
+	keys := make([]string, 0, len(configMap.Data))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var objs []*unstructured.Unstructured
+	var csvName string
+	for _, key := range keys {
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(configMap.Data[key]), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, "", fmt.Errorf("invalid manifests in %s of config map %s: %w", key, configMap.Name, err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if obj.GetKind() == v1alpha1.ClusterServiceVersionKind {
+				csvObjs, err := getCSVObjects(obj, cr.Namespace)
+				if err != nil {
+					return nil, "", err
+				}
+				objs = append(objs, csvObjs...)
+				csvName = obj.GetName()
+				continue
+			}
+			if err := r.setNamespace(obj, cr.Namespace); err != nil {
+				return nil, "", err
+			}
+			objs = append(objs, obj)
+		}
+	}
+
+	if err := validateManifests(objs, cr.Namespace); err != nil {
+		return nil, "", fmt.Errorf("invalid manifests in config map %s: %w", configMap.Name, err)
+	}
+
+	// the CRDs are installed first
+	sort.SliceStable(objs, func(i, j int) bool {
+		return objs[i].GetKind() == customResourceDefinitionKind && objs[j].GetKind() != customResourceDefinitionKind
+	})
+	return objs, csvName, nil
+}
+
+// validateManifests restricts the objects installed with the permissions of the DBaaS operator to the editors of the
+// manifests: the kinds of an operator bundle, and the custom resources of its CRDs, in the DBaaSPlatform namespace if
+// namespaced. The roles have no wildcard or privilege escalating rules, and the bindings only bind the roles of the
+// manifests.
+func validateManifests(objs []*unstructured.Unstructured, namespace string) error {
+	kinds := map[schema.GroupKind]bool{}
+	for groupKind := range manifestKinds {
+		kinds[groupKind] = true
+	}
+	roles := map[string]bool{}
+	for _, obj := range objs {
+		switch obj.GetKind() {
+		case customResourceDefinitionKind:
+			group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+			kinds[schema.GroupKind{Group: group, Kind: kind}] = true
+		case "Role", "ClusterRole":
+			roles[obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName()] = true
+		}
+	}
+
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if !kinds[gvk.GroupKind()] {
+			return fmt.Errorf("%s %s is not a kind of an operator bundle", gvk.Kind, obj.GetName())
+		}
+		if obj.GetNamespace() != "" && obj.GetNamespace() != namespace {
+			return fmt.Errorf("%s %s must be installed in the %s namespace", gvk.Kind, obj.GetName(), namespace)
+		}
+		if gvk.Group != rbacv1.GroupName {
+			continue
+		}
+
+		switch gvk.Kind {
+		case "Role", "ClusterRole":
+			role := &rbacv1.ClusterRole{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, role); err != nil {
+				return fmt.Errorf("invalid %s %s: %w", gvk.Kind, obj.GetName(), err)
+			}
+			if err := validateRole(role); err != nil {
+				return fmt.Errorf("%s %s %w", gvk.Kind, obj.GetName(), err)
+			}
+		case "RoleBinding", "ClusterRoleBinding":
+			binding := &rbacv1.RoleBinding{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, binding); err != nil {
+				return fmt.Errorf("invalid %s %s: %w", gvk.Kind, obj.GetName(), err)
+			}
+			roleNamespace := ""
+			if binding.RoleRef.Kind == "Role" {
+				roleNamespace = obj.GetNamespace()
+			}
+			if !roles[binding.RoleRef.Kind+"/"+roleNamespace+"/"+binding.RoleRef.Name] {
+				return fmt.Errorf("%s %s binds the %s %s, which is not part of the manifests", gvk.Kind, obj.GetName(),
+					binding.RoleRef.Kind, binding.RoleRef.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRole checks that a role of the manifests neither grants wildcard nor privilege escalating permissions, nor
+// extends the permissions of other roles
+func validateRole(role *rbacv1.ClusterRole) error {
+	if role.AggregationRule != nil {
+		return fmt.Errorf("must not aggregate other cluster roles")
+	}
+	for label := range role.Labels {
+		if strings.HasPrefix(label, "rbac.authorization.k8s.io/aggregate-to-") {
+			return fmt.Errorf("must not be aggregated to other cluster roles")
+		}
+	}
+	for i, rule := range role.Rules {
+		if len(rule.NonResourceURLs) > 0 {
+			return fmt.Errorf("rule %d must not grant non-resource URLs", i)
+		}
+		for _, values := range [][]string{rule.APIGroups, rule.Resources, rule.Verbs} {
+			for _, value := range values {
+				if value == rbacv1.APIGroupAll {
+					return fmt.Errorf("rule %d must not use wildcards", i)
+				}
+			}
+		}
+		for _, verb := range rule.Verbs {
+			if verb == "escalate" || verb == "bind" || verb == "impersonate" {
+				return fmt.Errorf("rule %d must not grant the %s verb", i, verb)
+			}
+		}
+	}
+	return nil
+}
+
+// setNamespace installs the namespaced objects without a namespace in the DBaaSPlatform namespace
+func (r *ManifestsReconciler) setNamespace(obj *unstructured.Unstructured, namespace string) error {
+	if obj.GetNamespace() != "" {
+		return nil
+	}
+	gvk := obj.GroupVersionKind()
+	mapping, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) && obj.GetKind() != customResourceDefinitionKind {
+			// custom resources of the manifests CRDs
+			obj.SetNamespace(namespace)
+			return nil
+		}
+		return err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		obj.SetNamespace(namespace)
+	}
+	return nil
+}
+
+func (r *ManifestsReconciler) applyObject(ctx context.Context, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		r.logger.Info("Installing provider operator object", "platform", r.platform, "kind", obj.GetKind(), "name", obj.GetName())
+		return r.client.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	// the status is not part of the manifests
+	if status, found := existing.Object["status"]; found {
+		obj.Object["status"] = status
+	}
+	return r.client.Update(ctx, obj)
+}
+
+// applySubscriptionConfig sets the configuration of a provider operator on a deployment of the manifests, the way OLM
+// applies the config of a subscription
+func applySubscriptionConfig(obj *unstructured.Unstructured, config *v1alpha1.SubscriptionConfig) error {
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+		return err
+	}
+
+	podSpec := &deployment.Spec.Template.Spec
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		for _, env := range config.Env {
+			container.Env = setEnvVar(container.Env, env)
+		}
+		if config.Resources != nil {
+			container.Resources = *config.Resources
+		}
+	}
+	if len(config.NodeSelector) > 0 {
+		podSpec.NodeSelector = config.NodeSelector
+	}
+	for _, toleration := range config.Tolerations {
+		if !containsToleration(podSpec.Tolerations, toleration) {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment)
+	if err != nil {
+		return err
+	}
+	obj.Object = content
+	return nil
+}
+
+// setEnvVar overrides the variable of the same name, or appends the variable
+func setEnvVar(envs []corev1.EnvVar, env corev1.EnvVar) []corev1.EnvVar {
+	for i := range envs {
+		if envs[i].Name == env.Name {
+			envs[i] = env
+			return envs
+		}
+	}
+	return append(envs, env)
+}
+
+func containsToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(&toleration) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCSVObjects returns the objects OLM creates for the deployment install strategy of a CSV
+func getCSVObjects(obj *unstructured.Unstructured, namespace string) ([]*unstructured.Unstructured, error) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, csv); err != nil {
+		return nil, fmt.Errorf("invalid CSV %s: %w", obj.GetName(), err)
+	}
+	strategy := csv.Spec.InstallStrategy.StrategySpec
+
+	var objs []runtime.Object
+	serviceAccounts := map[string]bool{}
+	addServiceAccount := func(name string) {
+		if !serviceAccounts[name] {
+			serviceAccounts[name] = true
+			objs = append(objs, &corev1.ServiceAccount{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			})
+		}
+	}
+	subject := func(name string) []rbacv1.Subject {
+		return []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}}
+	}
+
+	for _, permission := range strategy.Permissions {
+		addServiceAccount(permission.ServiceAccountName)
+		name := csv.Name + "-" + permission.ServiceAccountName
+		objs = append(objs,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Rules:      permission.Rules,
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+				Subjects:   subject(permission.ServiceAccountName),
+			})
+	}
+	for _, permission := range strategy.ClusterPermissions {
+		addServiceAccount(permission.ServiceAccountName)
+		name := csv.Name + "-" + permission.ServiceAccountName
+		objs = append(objs,
+			&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Rules:      permission.Rules,
+			},
+			&rbacv1.ClusterRoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+				Subjects:   subject(permission.ServiceAccountName),
+			})
+	}
+	for _, deploymentSpec := range strategy.DeploymentSpecs {
+		objs = append(objs, &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: deploymentSpec.Name, Namespace: namespace, Labels: deploymentSpec.Label},
+			Spec:       deploymentSpec.Spec,
+		})
+	}
+
+	unstructuredObjs := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		unstructuredObjs = append(unstructuredObjs, &unstructured.Unstructured{Object: content})
+	}
+	return unstructuredObjs, nil
+}
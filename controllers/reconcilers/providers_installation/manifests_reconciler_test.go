@@ -0,0 +1,256 @@
+package providers_installation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+)
+
+const testBundleManifests = `
+apiVersion: operators.coreos.com/v1alpha1
+kind: ClusterServiceVersion
+metadata:
+  name: mongodb-atlas-kubernetes.v0.2.0
+spec:
+  install:
+    strategy: deployment
+    spec:
+      clusterPermissions:
+      - serviceAccountName: mongodb-atlas-operator
+        rules:
+        - apiGroups: ["dbaas.redhat.com"]
+          resources: ["atlasinventories", "atlasconnections"]
+          verbs: ["get", "list", "watch", "update"]
+      deployments:
+      - name: mongodb-atlas-operator
+        spec:
+          selector:
+            matchLabels:
+              app: mongodb-atlas-operator
+          template:
+            metadata:
+              labels:
+                app: mongodb-atlas-operator
+            spec:
+              serviceAccountName: mongodb-atlas-operator
+              containers:
+              - name: manager
+                image: quay.io/mongodb/mongodb-atlas-operator:0.2.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: mongodb-atlas-operator-config
+data:
+  key: value
+`
+
+const testCRDManifests = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: atlasinventories.dbaas.redhat.com
+spec:
+  group: dbaas.redhat.com
+  names:
+    kind: AtlasInventory
+    plural: atlasinventories
+  scope: Namespaced
+`
+
+var _ = Describe("Providers manifests reconciler", func() {
+	const namespace = "test-namespace"
+	ctx := context.Background()
+	platform := v1.MongoDBAtlasInstallation
+	config := reconcilers.InstallationPlatforms[platform]
+
+	var c client.Client
+	var cr *v1.DBaaSPlatform
+	var r *ManifestsReconciler
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1.AddToScheme(scheme)).Should(Succeed())
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+		mapper.Add(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: customResourceDefinitionKind}, meta.RESTScopeRoot)
+		cr = &v1.DBaaSPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "dbaas-platform", Namespace: namespace},
+			Spec: v1.DBaaSPlatformSpec{
+				Providers: []v1.DBaaSPlatformProvider{
+					{Name: platform, ManifestsRef: &corev1.LocalObjectReference{Name: "mongodb-atlas-manifests"}},
+				},
+			},
+		}
+		manifests := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "mongodb-atlas-manifests", Namespace: namespace},
+			Data:       map[string]string{"bundle.yaml": testBundleManifests},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr, manifests).Build()
+		r = NewManifestsReconciler(c, scheme, mapper, ctrl.Log, platform, config).(*ManifestsReconciler)
+	})
+
+	It("should install the provider operator from the manifests of a bundle", func() {
+		status := &v1.DBaaSPlatformStatus{}
+		Expect(r.Reconcile(ctx, cr, status)).Should(Equal(v1.ResultInProgress))
+
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-operator"}, &corev1.ServiceAccount{})).Should(Succeed())
+		clusterRole := &rbacv1.ClusterRole{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "mongodb-atlas-kubernetes.v0.2.0-mongodb-atlas-operator"}, clusterRole)).Should(Succeed())
+		Expect(clusterRole.Rules).Should(HaveLen(1))
+		clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "mongodb-atlas-kubernetes.v0.2.0-mongodb-atlas-operator"}, clusterRoleBinding)).Should(Succeed())
+		Expect(clusterRoleBinding.Subjects).Should(Equal([]rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: "mongodb-atlas-operator", Namespace: namespace},
+		}))
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-operator-config"}, &corev1.ConfigMap{})).Should(Succeed())
+
+		deployment := &appsv1.Deployment{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-operator"}, deployment)).Should(Succeed())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).Should(Equal("quay.io/mongodb/mongodb-atlas-operator:0.2.0"))
+		deployment.Status.ReadyReplicas = 1
+		Expect(c.Status().Update(ctx, deployment)).Should(Succeed())
+
+		Expect(r.Reconcile(ctx, cr, status)).Should(Equal(v1.ResultSuccess))
+		Expect(status.Providers).Should(Equal([]v1.DBaaSPlatformProviderStatus{
+			{Name: platform, InstalledCSV: "mongodb-atlas-kubernetes.v0.2.0"},
+		}))
+
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1.ResultSuccess))
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-operator"}, deployment)
+		Expect(errors.IsNotFound(err)).Should(BeTrue())
+		err = c.Get(ctx, client.ObjectKey{Name: "mongodb-atlas-kubernetes.v0.2.0-mongodb-atlas-operator"}, clusterRole)
+		Expect(errors.IsNotFound(err)).Should(BeTrue())
+	})
+
+	It("should configure the deployments of the manifests", func() {
+		syncPeriod := 60
+		cr.Spec.SyncPeriod = &syncPeriod
+		cr.Spec.Providers[0].Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}
+		cr.Spec.Providers[0].NodeSelector = map[string]string{"node-role.kubernetes.io/infra": ""}
+		cr.Spec.Providers[0].Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+		Expect(r.Reconcile(ctx, cr, &v1.DBaaSPlatformStatus{})).Should(Equal(v1.ResultInProgress))
+
+		deployment := &appsv1.Deployment{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-operator"}, deployment)).Should(Succeed())
+		podSpec := deployment.Spec.Template.Spec
+		Expect(podSpec.Containers[0].Env).Should(Equal([]corev1.EnvVar{
+			{Name: v1.SyncPeriodEnvName, Value: "60"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		}))
+		Expect(podSpec.NodeSelector).Should(Equal(map[string]string{"node-role.kubernetes.io/infra": ""}))
+		Expect(podSpec.Tolerations).Should(Equal(cr.Spec.Providers[0].Tolerations))
+
+		// the updated settings are applied to the existing deployment
+		providerSyncPeriod := 30
+		cr.Spec.Providers[0].SyncPeriod = &providerSyncPeriod
+		Expect(r.Reconcile(ctx, cr, &v1.DBaaSPlatformStatus{})).Should(Equal(v1.ResultInProgress))
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-operator"}, deployment)).Should(Succeed())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Env[0]).Should(Equal(corev1.EnvVar{Name: v1.SyncPeriodEnvName, Value: "30"}))
+	})
+
+	It("should report invalid manifests", func() {
+		manifests := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-manifests"}, manifests)).Should(Succeed())
+		manifests.Data = map[string]string{"operator.yaml": "kind: [Deployment"}
+		Expect(c.Update(ctx, manifests)).Should(Succeed())
+		status, err := r.Reconcile(ctx, cr, &v1.DBaaSPlatformStatus{})
+		Expect(status).Should(Equal(v1.ResultFailed))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should not install manifests granting the permissions of the operator", func() {
+		for _, manifests := range []string{
+			// wildcard rules
+			`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: provider-operator
+rules:
+- apiGroups: ["*"]
+  resources: ["*"]
+  verbs: ["*"]
+`,
+			// bindings of roles which are not part of the manifests
+			`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: provider-operator
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+subjects:
+- kind: ServiceAccount
+  name: provider-operator
+  namespace: test-namespace
+`,
+			// objects of other namespaces
+			`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: provider-operator
+  namespace: kube-system
+`,
+			// kinds which are not part of an operator bundle
+			`
+apiVersion: admissionregistration.k8s.io/v1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: provider-operator
+`,
+		} {
+			manifestsConfigMap := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-manifests"}, manifestsConfigMap)).Should(Succeed())
+			manifestsConfigMap.Data = map[string]string{"operator.yaml": manifests}
+			Expect(c.Update(ctx, manifestsConfigMap)).Should(Succeed())
+			status, err := r.Reconcile(ctx, cr, &v1.DBaaSPlatformStatus{})
+			Expect(status).Should(Equal(v1.ResultFailed))
+			Expect(err).Should(HaveOccurred())
+		}
+		err := c.Get(ctx, client.ObjectKey{Name: "provider-operator"}, &rbacv1.ClusterRoleBinding{})
+		Expect(errors.IsNotFound(err)).Should(BeTrue())
+	})
+
+	It("should only delete the CRDs of the manifests when purging", func() {
+		manifests := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "mongodb-atlas-manifests"}, manifests)).Should(Succeed())
+		manifests.Data["crds.yaml"] = testCRDManifests
+		Expect(c.Update(ctx, manifests)).Should(Succeed())
+		Expect(r.GetProviderKinds(ctx, cr)).Should(Equal([]string{"AtlasInventory"}))
+		Expect(r.Reconcile(ctx, cr, &v1.DBaaSPlatformStatus{})).Should(Equal(v1.ResultInProgress))
+
+		crd := &unstructured.Unstructured{}
+		crd.SetAPIVersion("apiextensions.k8s.io/v1")
+		crd.SetKind(customResourceDefinitionKind)
+		Expect(c.Get(ctx, client.ObjectKey{Name: "atlasinventories.dbaas.redhat.com"}, crd)).Should(Succeed())
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1.ResultSuccess))
+		Expect(c.Get(ctx, client.ObjectKey{Name: "atlasinventories.dbaas.redhat.com"}, crd)).Should(Succeed())
+
+		cr.Spec.Uninstall = &v1.DBaaSPlatformUninstall{Purge: true}
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1.ResultSuccess))
+		err := c.Get(ctx, client.ObjectKey{Name: "atlasinventories.dbaas.redhat.com"}, crd)
+		Expect(errors.IsNotFound(err)).Should(BeTrue())
+	})
+})
@@ -2,14 +2,20 @@ package providers_installation
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	coreosv1 "github.com/operator-framework/api/pkg/operators/v1"
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"golang.org/x/mod/semver"
 	apiv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,43 +26,69 @@ import (
 )
 
 type Reconciler struct {
-	client client.Client
-	logger logr.Logger
-	scheme *runtime.Scheme
-	config v1.PlatformConfig
+	client   client.Client
+	logger   logr.Logger
+	scheme   *runtime.Scheme
+	platform v1.PlatformsName
+	config   v1.PlatformConfig
+	// reads the operator deployments, which are only cached in the install namespace
+	reader client.Reader
 }
 
-func NewReconciler(client client.Client, scheme *runtime.Scheme, logger logr.Logger, config v1.PlatformConfig) reconcilers.PlatformReconciler {
+func NewReconciler(client client.Client, reader client.Reader, scheme *runtime.Scheme, logger logr.Logger, platform v1.PlatformsName, config v1.PlatformConfig) reconcilers.PlatformReconciler {
 	return &Reconciler{
-		client: client,
-		scheme: scheme,
-		logger: logger,
-		config: config,
+		client:   client,
+		reader:   reader,
+		scheme:   scheme,
+		logger:   logger,
+		platform: platform,
+		config:   config,
 	}
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, cr *v1.DBaaSPlatform, status2 *v1.DBaaSPlatformStatus) (v1.PlatformsInstlnStatus, error) {
 
-	status, err := r.reconcileCatalogSource(ctx)
-	if status != v1.ResultSuccess {
-		return status, err
+	subscription, managed, conflict, err := r.resolveSubscription(ctx, cr)
+	if err != nil {
+		return v1.ResultFailed, err
 	}
-
-	status, err = r.reconcileSubscription(cr, ctx)
-	if status != v1.ResultSuccess {
-		return status, err
+	if conflict != "" {
+		r.logger.Info("Provider operator subscription conflict", "platform", r.platform, "conflict", conflict)
 	}
 
-	status, err = r.reconcileOperatorGroup(ctx)
-	if status != v1.ResultSuccess {
-		return status, err
+	// a provider operator installed by an admin is used as is
+	var approvalBlocked string
+	if managed {
+		status, err := r.reconcileCatalogSource(ctx)
+		if status != v1.ResultSuccess {
+			return status, err
+		}
+
+		status, err = r.reconcileSubscription(cr, ctx, subscription)
+		if status != v1.ResultSuccess {
+			return status, err
+		}
+
+		status, err = r.reconcileOperatorGroup(ctx)
+		if status != v1.ResultSuccess {
+			return status, err
+		}
+		status, approvalBlocked, err = r.reconcileInstallPlans(cr, ctx, subscription)
+		if status != v1.ResultSuccess {
+			return status, err
+		}
+		// the operator may never become available while the install plans are blocked, which is reported early
+		if approvalBlocked != "" && status2 != nil {
+			status2.SetProviderStatus(getProviderStatus(r.platform, subscription, managed, conflict, approvalBlocked))
+		}
 	}
-	status, err = r.waitForOperator(cr, ctx)
+
+	status, err := r.waitForOperator(ctx, subscription.Namespace)
 	if status != v1.ResultSuccess {
 		return status, err
 	}
 
-	status, err = r.reconcileCSV(cr, ctx)
+	status, err = r.reconcileCSV(cr, ctx, status2, subscription, managed, conflict, approvalBlocked)
 	if status != v1.ResultSuccess {
 		return status, err
 	}
@@ -64,14 +96,132 @@ func (r *Reconciler) Reconcile(ctx context.Context, cr *v1.DBaaSPlatform, status
 
 }
 
+// resolveSubscription returns the subscription of the provider operator package, and whether the DBaaS operator manages
+// it: the subscription created by the DBaaS operator, or adopted from an admin if the provider settings allow it.
+// Otherwise, an existing subscription created by an admin is used as is, in any namespace. It also returns why the
+// existing subscriptions conflict with the provider settings, such as multiple subscriptions of the package.
+func (r *Reconciler) resolveSubscription(ctx context.Context, cr *v1.DBaaSPlatform) (*v1alpha1.Subscription, bool, string, error) {
+	subscriptionList := &v1alpha1.SubscriptionList{}
+	if err := r.client.List(ctx, subscriptionList); err != nil {
+		return nil, false, "", err
+	}
+	var managed, foreign []*v1alpha1.Subscription
+	for i := range subscriptionList.Items {
+		subscription := &subscriptionList.Items[i]
+		if metav1.IsControlledBy(subscription, cr) {
+			managed = append(managed, subscription)
+		} else if subscription.Spec != nil && subscription.Spec.Package == r.config.PackageName ||
+			subscription.Namespace == cr.Namespace && subscription.Name == r.config.Name+"-subscription" {
+			foreign = append(foreign, subscription)
+		}
+	}
+
+	var conflict string
+	if len(foreign) > 0 {
+		names := make([]string, 0, len(foreign))
+		for _, subscription := range foreign {
+			names = append(names, subscription.Namespace+"/"+subscription.Name)
+		}
+		sort.Strings(names)
+		conflict = fmt.Sprintf("the package %s is subscribed by %s, not managed by the DBaaS operator", r.config.PackageName, strings.Join(names, ", "))
+	}
+
+	if len(managed) > 0 {
+		return managed[0], true, conflict, nil
+	}
+	if len(foreign) == 0 {
+		return reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription"), true, "", nil
+	}
+	if len(foreign) > 1 {
+		return foreign[0], false, conflict, nil
+	}
+
+	subscription := foreign[0]
+	provider := cr.Spec.GetProvider(r.platform)
+	if provider != nil && provider.AdoptSubscription {
+		if subscription.Namespace == cr.Namespace {
+			r.logger.Info("Adopting provider operator subscription", "platform", r.platform, "subscription", subscription.Name)
+			return subscription, true, "", nil
+		}
+		return subscription, false, fmt.Sprintf("the subscription %s/%s of the package %s cannot be adopted outside of the %s namespace",
+			subscription.Namespace, subscription.Name, r.config.PackageName, cr.Namespace), nil
+	}
+	if provider != nil && (provider.Channel != "" || provider.CSV != "" || provider.InstallPlanApproval != "" ||
+		provider.SyncPeriod != nil || len(provider.Env) > 0 || provider.Resources != nil || len(provider.NodeSelector) > 0 || len(provider.Tolerations) > 0) {
+		return subscription, false, fmt.Sprintf("the provider settings are not applied to the subscription %s/%s of the package %s, not managed by the DBaaS operator",
+			subscription.Namespace, subscription.Name, r.config.PackageName), nil
+	}
+	return subscription, false, "", nil
+}
+
+// CheckHealth reports a missing subscription or CSV, a failed CSV, and an unhealthy provider operator deployment
+func (r *Reconciler) CheckHealth(ctx context.Context, cr *v1.DBaaSPlatform) (string, error) {
+	subscription, _, _, err := r.resolveSubscription(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	if subscription.ResourceVersion == "" {
+		return fmt.Sprintf("the subscription %s is missing", subscription.Name), nil
+	}
+
+	if subscription.Status.InstalledCSV != "" {
+		csv := reconcilers.GetClusterServiceVersion(subscription.Namespace, subscription.Status.InstalledCSV)
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(csv), csv); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Sprintf("the CSV %s is missing", csv.Name), nil
+			}
+			return "", err
+		}
+		if csv.Status.Phase == v1alpha1.CSVPhaseFailed {
+			return fmt.Sprintf("the CSV %s failed: %s", csv.Name, csv.Status.Message), nil
+		}
+	}
+
+	return reconcilers.CheckDeploymentHealth(ctx, r.reader, subscription.Namespace, r.config.DeploymentName)
+}
+
+// Cleanup uninstalls the provider operator, and deletes its CRDs when the platforms are purged. A provider operator
+// installed by an admin is left installed.
 func (r *Reconciler) Cleanup(ctx context.Context, cr *v1.DBaaSPlatform) (v1.PlatformsInstlnStatus, error) {
 
-	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
-	err := r.client.Delete(ctx, subscription)
+	subscription, managed, _, err := r.resolveSubscription(ctx, cr)
+	if err != nil {
+		return v1.ResultFailed, err
+	}
+	if !managed {
+		catalogSource := reconcilers.GetCatalogSource(reconcilers.CATALOG_NAMESPACE, r.config.Name+"-catalogsource")
+		if err := r.client.Delete(ctx, catalogSource); err != nil && !errors.IsNotFound(err) {
+			return v1.ResultFailed, err
+		}
+		return v1.ResultSuccess, nil
+	}
+	csvName := r.config.CSV
+	if subscription.Status.InstalledCSV != "" {
+		csvName = subscription.Status.InstalledCSV
+	}
+	err = r.client.Delete(ctx, subscription)
 	if err != nil && !errors.IsNotFound(err) {
 		return v1.ResultFailed, err
 	}
 
+	// the CRDs are deleted before the CSV listing them
+	if cr.Spec.IsPurged() {
+		crds, err := r.getOwnedCRDs(ctx, cr.Namespace, csvName)
+		if err != nil {
+			return v1.ResultFailed, err
+		}
+		for _, crd := range crds {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("apiextensions.k8s.io/v1")
+			obj.SetKind(customResourceDefinitionKind)
+			obj.SetName(crd.Name)
+			err := r.client.Delete(ctx, obj)
+			if err != nil && !errors.IsNotFound(err) {
+				return v1.ResultFailed, err
+			}
+		}
+	}
+
 	catalogSource := reconcilers.GetCatalogSource(reconcilers.CATALOG_NAMESPACE, r.config.Name+"-catalogsource")
 	err = r.client.Delete(ctx, catalogSource)
 	if err != nil && !errors.IsNotFound(err) {
@@ -95,7 +245,7 @@ func (r *Reconciler) Cleanup(ctx context.Context, cr *v1.DBaaSPlatform) (v1.Plat
 		}
 	}
 
-	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, r.config.CSV)
+	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, csvName)
 	err = r.client.Delete(ctx, csv)
 	if err != nil && !errors.IsNotFound(err) {
 		return v1.ResultFailed, err
@@ -103,9 +253,41 @@ func (r *Reconciler) Cleanup(ctx context.Context, cr *v1.DBaaSPlatform) (v1.Plat
 
 	return v1.ResultSuccess, nil
 }
-func (r *Reconciler) reconcileSubscription(cr *v1.DBaaSPlatform, ctx context.Context) (v1.PlatformsInstlnStatus, error) {
 
-	subscription := reconcilers.GetSubscription(cr.Namespace, r.config.Name+"-subscription")
+// GetProviderKinds returns the kinds of the CRDs owned by the installed CSV of the provider operator
+func (r *Reconciler) GetProviderKinds(ctx context.Context, cr *v1.DBaaSPlatform) ([]string, error) {
+	subscription, _, _, err := r.resolveSubscription(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status.InstalledCSV == "" {
+		return nil, nil
+	}
+	crds, err := r.getOwnedCRDs(ctx, subscription.Namespace, subscription.Status.InstalledCSV)
+	if err != nil {
+		return nil, err
+	}
+	kinds := make([]string, 0, len(crds))
+	for _, crd := range crds {
+		kinds = append(kinds, crd.Kind)
+	}
+	return kinds, nil
+}
+
+// getOwnedCRDs returns the CRDs owned by a CSV, or none if the CSV is not found
+func (r *Reconciler) getOwnedCRDs(ctx context.Context, namespace string, csvName string) ([]v1alpha1.CRDDescription, error) {
+	csv := reconcilers.GetClusterServiceVersion(namespace, csvName)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(csv), csv); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return csv.Spec.CustomResourceDefinitions.Owned, nil
+}
+
+func (r *Reconciler) reconcileSubscription(cr *v1.DBaaSPlatform, ctx context.Context, subscription *v1alpha1.Subscription) (v1.PlatformsInstlnStatus, error) {
+
 	catalogsource := reconcilers.GetCatalogSource(reconcilers.CATALOG_NAMESPACE, r.config.Name+"-catalogsource")
 	_, err := controllerutil.CreateOrUpdate(ctx, r.client, subscription, func() error {
 		if err := ctrl.SetControllerReference(cr, subscription, r.scheme); err != nil {
@@ -118,16 +300,17 @@ func (r *Reconciler) reconcileSubscription(cr *v1.DBaaSPlatform, ctx context.Con
 			Channel:                r.config.Channel,
 			InstallPlanApproval:    v1alpha1.ApprovalAutomatic,
 		}
-		if cr.Spec.SyncPeriod != nil {
-			subscription.Spec.Config = &v1alpha1.SubscriptionConfig{
-				Env: []corev1.EnvVar{
-					{
-						Name:  "SYNC_PERIOD_MIN",
-						Value: strconv.Itoa(*cr.Spec.SyncPeriod),
-					},
-				},
+		if provider := cr.Spec.GetProvider(r.platform); provider != nil {
+			if provider.Channel != "" {
+				subscription.Spec.Channel = provider.Channel
+			}
+			if provider.InstallPlanApproval == string(v1alpha1.ApprovalManual) || provider.CSV != "" {
+				subscription.Spec.InstallPlanApproval = v1alpha1.ApprovalManual
+				subscription.Spec.StartingCSV = provider.CSV
 			}
 		}
+		// OLM applies the changes of the config to the deployments of the installed operator
+		subscription.Spec.Config = getSubscriptionConfig(cr, r.platform)
 
 		return nil
 	})
@@ -137,20 +320,77 @@ func (r *Reconciler) reconcileSubscription(cr *v1.DBaaSPlatform, ctx context.Con
 	}
 	return v1.ResultSuccess, nil
 }
+
+// getSubscriptionConfig returns the configuration of a provider operator deployment, from the sync period and the
+// provider settings of the platform, or nil if there is none
+func getSubscriptionConfig(cr *v1.DBaaSPlatform, platform v1.PlatformsName) *v1alpha1.SubscriptionConfig {
+	config := &v1alpha1.SubscriptionConfig{}
+	if syncPeriod := cr.Spec.GetSyncPeriod(platform); syncPeriod != nil {
+		config.Env = append(config.Env, corev1.EnvVar{
+			Name:  v1.SyncPeriodEnvName,
+			Value: strconv.Itoa(*syncPeriod),
+		})
+	}
+	if provider := cr.Spec.GetProvider(platform); provider != nil {
+		config.Env = append(config.Env, provider.Env...)
+		config.Resources = provider.Resources
+		config.NodeSelector = provider.NodeSelector
+		config.Tolerations = provider.Tolerations
+	}
+	if len(config.Env) == 0 && config.Resources == nil && len(config.NodeSelector) == 0 && len(config.Tolerations) == 0 {
+		return nil
+	}
+	return config
+}
+
+// reconcileOperatorGroup creates the operator group of the provider operators if their namespace has none, the existing
+// operator groups are never modified
 func (r *Reconciler) reconcileOperatorGroup(ctx context.Context) (v1.PlatformsInstlnStatus, error) {
 
-	operatorgroup := reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, "global-operators")
-	_, err := controllerutil.CreateOrUpdate(ctx, r.client, operatorgroup, func() error {
-		operatorgroup.Spec = coreosv1.OperatorGroupSpec{}
+	operatorgroups := &coreosv1.OperatorGroupList{}
+	if err := r.client.List(ctx, operatorgroups, client.InNamespace(reconcilers.INSTALL_NAMESPACE)); err != nil {
+		return v1.ResultFailed, err
+	}
+	if len(operatorgroups.Items) > 0 {
+		return v1.ResultSuccess, nil
+	}
 
-		return nil
-	})
-	if err != nil {
+	// an operator group created by the operator is deleted with the platforms
+	operatorgroup := reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, reconcilers.OPERATOR_GROUP_NAME)
+	operatorgroup.Labels = map[string]string{reconcilers.MANAGED_BY_LABEL: reconcilers.MANAGED_BY_VALUE}
+	if err := r.client.Create(ctx, operatorgroup); err != nil && !errors.IsAlreadyExists(err) {
 		return v1.ResultFailed, err
 	}
 
 	return v1.ResultSuccess, nil
 }
+
+// CleanupOperatorGroup deletes the operator group of the provider operators once they are all uninstalled, if it was
+// created by the operator
+func CleanupOperatorGroup(ctx context.Context, c client.Client) error {
+	operatorgroup := reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, reconcilers.OPERATOR_GROUP_NAME)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(operatorgroup), operatorgroup); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if operatorgroup.Labels[reconcilers.MANAGED_BY_LABEL] != reconcilers.MANAGED_BY_VALUE {
+		return nil
+	}
+	subscriptions := &v1alpha1.SubscriptionList{}
+	if err := c.List(ctx, subscriptions, client.InNamespace(operatorgroup.Namespace)); err != nil {
+		return err
+	}
+	if len(subscriptions.Items) > 0 {
+		return nil
+	}
+	if err := c.Delete(ctx, operatorgroup); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (r *Reconciler) reconcileCatalogSource(ctx context.Context) (v1.PlatformsInstlnStatus, error) {
 	catalogsource := reconcilers.GetCatalogSource(reconcilers.CATALOG_NAMESPACE, r.config.Name+"-catalogsource")
 	_, err := controllerutil.CreateOrUpdate(ctx, r.client, catalogsource, func() error {
@@ -167,13 +407,13 @@ func (r *Reconciler) reconcileCatalogSource(ctx context.Context) (v1.PlatformsIn
 	return v1.ResultSuccess, nil
 }
 
-func (r *Reconciler) waitForOperator(cr *v1.DBaaSPlatform, ctx context.Context) (v1.PlatformsInstlnStatus, error) {
+func (r *Reconciler) waitForOperator(ctx context.Context, namespace string) (v1.PlatformsInstlnStatus, error) {
 
 	deployments := &apiv1.DeploymentList{}
 	opts := &client.ListOptions{
-		Namespace: cr.Namespace,
+		Namespace: namespace,
 	}
-	err := r.client.List(ctx, deployments, opts)
+	err := r.reader.List(ctx, deployments, opts)
 	if err != nil {
 		return v1.ResultFailed, err
 	}
@@ -188,14 +428,93 @@ func (r *Reconciler) waitForOperator(cr *v1.DBaaSPlatform, ctx context.Context)
 	return v1.ResultInProgress, nil
 }
 
-func (r *Reconciler) reconcileCSV(cr *v1.DBaaSPlatform, ctx context.Context) (v1.PlatformsInstlnStatus, error) {
-	csv := reconcilers.GetClusterServiceVersion(cr.Namespace, r.config.CSV)
+// reconcileInstallPlans approves the pending install plans of the provider subscription with the Manual approval mode:
+// the initial installation, and the upgrades following the CSV replacement chain up to the pinned CSV. Only the CSVs
+// of the provider operator package are evaluated: the install plans batching the CSVs of other packages, which OLM
+// resolves together in the same namespace, are not approved, as this would install the other operators as well.
+// It returns why the pending install plans are not approved, if the provider settings cannot be applied.
+func (r *Reconciler) reconcileInstallPlans(cr *v1.DBaaSPlatform, ctx context.Context, subscription *v1alpha1.Subscription) (v1.PlatformsInstlnStatus, string, error) {
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(subscription), subscription); err != nil {
+		if errors.IsNotFound(err) {
+			return v1.ResultInProgress, "", nil
+		}
+		return v1.ResultFailed, "", err
+	}
+	if subscription.Spec == nil || subscription.Spec.InstallPlanApproval != v1alpha1.ApprovalManual {
+		return v1.ResultSuccess, "", nil
+	}
+
+	var pinnedCSV string
+	if provider := cr.Spec.GetProvider(r.platform); provider != nil {
+		pinnedCSV = provider.CSV
+	}
+	var blocked []string
+	if pinnedCSV != "" && isOlderCSV(pinnedCSV, subscription.Status.InstalledCSV) {
+		blocked = append(blocked, fmt.Sprintf("the pinned CSV %s is older than the installed CSV %s, operators cannot be downgraded",
+			pinnedCSV, subscription.Status.InstalledCSV))
+	}
+
+	installPlans := &v1alpha1.InstallPlanList{}
+	if err := r.client.List(ctx, installPlans, client.InNamespace(subscription.Namespace)); err != nil {
+		return v1.ResultFailed, "", err
+	}
+	packageName, _ := splitCSVName(r.config.CSV)
+	for i := range installPlans.Items {
+		installPlan := &installPlans.Items[i]
+		if installPlan.Spec.Approved || !isOwnedBy(installPlan.OwnerReferences, subscription) {
+			continue
+		}
+		csvNames, otherCSVNames := partitionCSVNames(installPlan.Spec.ClusterServiceVersionNames, packageName)
+		if len(csvNames) == 0 || !isApprovable(csvNames, subscription.Status.InstalledCSV, pinnedCSV) {
+			continue
+		}
+		if len(otherCSVNames) > 0 {
+			blocked = append(blocked, fmt.Sprintf("the install plan %s also installs the CSVs %s of other packages, and must be approved manually",
+				installPlan.Name, strings.Join(otherCSVNames, ", ")))
+			continue
+		}
+		installPlan.Spec.Approved = true
+		if err := r.client.Update(ctx, installPlan); err != nil {
+			return v1.ResultFailed, "", err
+		}
+		r.logger.Info("Approved provider operator install plan", "installPlan", installPlan.Name, "csv", installPlan.Spec.ClusterServiceVersionNames)
+	}
+	return v1.ResultSuccess, strings.Join(blocked, "; "), nil
+}
+
+// reconcileCSV reports the installed provider operator version, and sets the DBaaSPlatform as owner of the CSV of a
+// managed subscription
+func (r *Reconciler) reconcileCSV(cr *v1.DBaaSPlatform, ctx context.Context, status *v1.DBaaSPlatformStatus, subscription *v1alpha1.Subscription,
+	managed bool, conflict, approvalBlocked string) (v1.PlatformsInstlnStatus, error) {
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(subscription), subscription); err != nil {
+		if errors.IsNotFound(err) {
+			return v1.ResultInProgress, nil
+		}
+		return v1.ResultFailed, err
+	}
+
+	providerStatus := getProviderStatus(r.platform, subscription, managed, conflict, approvalBlocked)
+	if status != nil {
+		status.SetProviderStatus(providerStatus)
+	}
+	if providerStatus.InstalledCSV == "" {
+		return v1.ResultInProgress, nil
+	}
+	if provider := cr.Spec.GetProvider(r.platform); managed && provider != nil && provider.CSV != "" &&
+		isOlderCSV(providerStatus.InstalledCSV, provider.CSV) {
+		return v1.ResultInProgress, nil
+	}
+
+	csv := reconcilers.GetClusterServiceVersion(subscription.Namespace, providerStatus.InstalledCSV)
 	if err := r.client.Get(ctx, client.ObjectKeyFromObject(csv), csv); err != nil {
 		if errors.IsNotFound(err) {
 			return v1.ResultInProgress, nil
 		}
 		return v1.ResultFailed, err
 	}
+	if !managed {
+		return v1.ResultSuccess, nil
+	}
 
 	if set, err := reconcilers.CheckOwnerReferenceSet(cr, csv, r.scheme); err != nil {
 		return v1.ResultFailed, err
@@ -211,3 +530,77 @@ func (r *Reconciler) reconcileCSV(cr *v1.DBaaSPlatform, ctx context.Context) (v1
 	}
 	return v1.ResultInProgress, nil
 }
+
+// getProviderStatus returns the status of a provider operator from its subscription
+func getProviderStatus(platform v1.PlatformsName, subscription *v1alpha1.Subscription, managed bool, conflict, approvalBlocked string) v1.DBaaSPlatformProviderStatus {
+	// the installed CSV follows the replacement chain as OLM upgrades the provider operator
+	providerStatus := v1.DBaaSPlatformProviderStatus{
+		Name:            platform,
+		InstalledCSV:    subscription.Status.InstalledCSV,
+		Conflict:        conflict,
+		ApprovalBlocked: approvalBlocked,
+	}
+	if !managed {
+		providerStatus.ExternalSubscription = subscription.Namespace + "/" + subscription.Name
+	}
+	if subscription.Status.CurrentCSV != subscription.Status.InstalledCSV &&
+		subscription.Status.State == v1alpha1.SubscriptionStateUpgradePending {
+		providerStatus.PendingCSV = subscription.Status.CurrentCSV
+	}
+	return providerStatus
+}
+
+func isOwnedBy(ownerReferences []metav1.OwnerReference, subscription *v1alpha1.Subscription) bool {
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.Kind == v1alpha1.SubscriptionKind && ownerReference.Name == subscription.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// isApprovable returns whether an install plan of the given CSVs can be approved: without a pinned CSV, only the initial
+// installation is approved, otherwise each CSV must be the pinned CSV or a previous version of the replacement chain
+func isApprovable(csvNames []string, installedCSV, pinnedCSV string) bool {
+	if pinnedCSV == "" {
+		return installedCSV == ""
+	}
+	for _, csvName := range csvNames {
+		if csvName != pinnedCSV && !isOlderCSV(csvName, pinnedCSV) {
+			return false
+		}
+	}
+	return len(csvNames) > 0
+}
+
+// partitionCSVNames splits the CSVs of an install plan between those of a package and those of other packages
+func partitionCSVNames(csvNames []string, packageName string) ([]string, []string) {
+	var packageCSVNames, otherCSVNames []string
+	for _, csvName := range csvNames {
+		if pkg, _ := splitCSVName(csvName); pkg == packageName {
+			packageCSVNames = append(packageCSVNames, csvName)
+		} else {
+			otherCSVNames = append(otherCSVNames, csvName)
+		}
+	}
+	return packageCSVNames, otherCSVNames
+}
+
+// isOlderCSV returns whether a CSV is a previous version of the same package as another CSV,
+// CSV names being formatted as <package>.v<semantic version>
+func isOlderCSV(csvName, otherCSVName string) bool {
+	pkg, version := splitCSVName(csvName)
+	otherPkg, otherVersion := splitCSVName(otherCSVName)
+	if pkg != otherPkg || !semver.IsValid(version) || !semver.IsValid(otherVersion) {
+		return false
+	}
+	return semver.Compare(version, otherVersion) < 0
+}
+
+func splitCSVName(csvName string) (string, string) {
+	i := strings.LastIndex(csvName, ".v")
+	if i < 0 {
+		return csvName, ""
+	}
+	return csvName[:i], csvName[i+1:]
+}
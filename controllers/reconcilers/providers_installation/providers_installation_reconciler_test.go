@@ -0,0 +1,435 @@
+package providers_installation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	coreosv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
+)
+
+var _ = Describe("Providers installation reconciler", func() {
+	const namespace = "test-namespace"
+	ctx := context.Background()
+	config := reconcilers.InstallationPlatforms[v1.MongoDBAtlasInstallation]
+
+	var scheme *runtime.Scheme
+	var c client.Client
+	var cr *v1.DBaaSPlatform
+	var r *Reconciler
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1.AddToScheme(scheme)).Should(Succeed())
+		cr = &v1.DBaaSPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "dbaas-platform", Namespace: namespace, UID: "platform-uid"},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build()
+		r = NewReconciler(c, c, scheme, ctrl.Log, v1.MongoDBAtlasInstallation, config).(*Reconciler)
+	})
+
+	ownSubscription := func() *v1alpha1.Subscription {
+		return reconcilers.GetSubscription(namespace, config.Name+"-subscription")
+	}
+	getSubscription := func() *v1alpha1.Subscription {
+		subscription := ownSubscription()
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(subscription), subscription)).Should(Succeed())
+		return subscription
+	}
+	createInstallPlan := func(name string, csvNames ...string) {
+		subscription := getSubscription()
+		installPlan := &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.SubscriptionKind, Name: subscription.Name, UID: "subscription-uid"},
+				},
+			},
+			Spec: v1alpha1.InstallPlanSpec{
+				ClusterServiceVersionNames: csvNames,
+				Approval:                   v1alpha1.ApprovalManual,
+			},
+		}
+		Expect(c.Create(ctx, installPlan)).Should(Succeed())
+	}
+	isApproved := func(name string) bool {
+		installPlan := &v1alpha1.InstallPlan{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, installPlan)).Should(Succeed())
+		return installPlan.Spec.Approved
+	}
+	expectInstallPlansReconciled := func(approvalBlocked string) {
+		status, blocked, err := r.reconcileInstallPlans(cr, ctx, ownSubscription())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).Should(Equal(v1.ResultSuccess))
+		Expect(blocked).Should(Equal(approvalBlocked))
+	}
+	setInstalledCSV := func(installedCSV, currentCSV string, state v1alpha1.SubscriptionState) {
+		subscription := getSubscription()
+		subscription.Status.InstalledCSV = installedCSV
+		subscription.Status.CurrentCSV = currentCSV
+		subscription.Status.State = state
+		Expect(c.Update(ctx, subscription)).Should(Succeed())
+	}
+
+	It("should subscribe with automatic approvals by default", func() {
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		subscription := getSubscription()
+		Expect(subscription.Spec.InstallPlanApproval).Should(Equal(v1alpha1.ApprovalAutomatic))
+		Expect(subscription.Spec.Channel).Should(Equal(config.Channel))
+		Expect(subscription.Spec.StartingCSV).Should(BeEmpty())
+	})
+
+	It("should configure the provider operator, and apply the changes of the settings", func() {
+		syncPeriod := 180
+		cr.Spec.SyncPeriod = &syncPeriod
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		Expect(getSubscription().Spec.Config).Should(Equal(&v1alpha1.SubscriptionConfig{
+			Env: []corev1.EnvVar{{Name: v1.SyncPeriodEnvName, Value: "180"}},
+		}))
+
+		providerSyncPeriod := 30
+		resources := &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		}
+		tolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+		cr.Spec.Providers = []v1.DBaaSPlatformProvider{
+			{
+				Name:         v1.MongoDBAtlasInstallation,
+				SyncPeriod:   &providerSyncPeriod,
+				Env:          []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+				Resources:    resources,
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:  tolerations,
+			},
+		}
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		config := getSubscription().Spec.Config
+		Expect(config.Env).Should(Equal([]corev1.EnvVar{
+			{Name: v1.SyncPeriodEnvName, Value: "30"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		}))
+		Expect(config.Resources.Limits.Memory().String()).Should(Equal("512Mi"))
+		Expect(config.NodeSelector).Should(Equal(map[string]string{"node-role.kubernetes.io/infra": ""}))
+		Expect(config.Tolerations).Should(Equal(tolerations))
+
+		cr.Spec.SyncPeriod = nil
+		cr.Spec.Providers = nil
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		Expect(getSubscription().Spec.Config).Should(BeNil())
+	})
+
+	It("should only approve the initial installation in the manual approval mode", func() {
+		cr.Spec.Providers = []v1.DBaaSPlatformProvider{
+			{Name: v1.MongoDBAtlasInstallation, InstallPlanApproval: "Manual", Channel: "stable"},
+		}
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		subscription := getSubscription()
+		Expect(subscription.Spec.InstallPlanApproval).Should(Equal(v1alpha1.ApprovalManual))
+		Expect(subscription.Spec.Channel).Should(Equal("stable"))
+
+		createInstallPlan("install-1", "mongodb-atlas-kubernetes.v0.2.0")
+		expectInstallPlansReconciled("")
+		Expect(isApproved("install-1")).Should(BeTrue())
+
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.2.0", "mongodb-atlas-kubernetes.v0.2.1", v1alpha1.SubscriptionStateUpgradePending)
+		createInstallPlan("install-2", "mongodb-atlas-kubernetes.v0.2.1")
+		expectInstallPlansReconciled("")
+		Expect(isApproved("install-2")).Should(BeFalse())
+
+		status := &v1.DBaaSPlatformStatus{}
+		Expect(r.reconcileCSV(cr, ctx, status, ownSubscription(), true, "", "")).Should(Equal(v1.ResultInProgress))
+		Expect(status.Providers).Should(Equal([]v1.DBaaSPlatformProviderStatus{
+			{
+				Name:         v1.MongoDBAtlasInstallation,
+				InstalledCSV: "mongodb-atlas-kubernetes.v0.2.0",
+				PendingCSV:   "mongodb-atlas-kubernetes.v0.2.1",
+			},
+		}))
+	})
+
+	It("should approve upgrades up to the pinned CSV", func() {
+		cr.Spec.Providers = []v1.DBaaSPlatformProvider{
+			{Name: v1.MongoDBAtlasInstallation, CSV: "mongodb-atlas-kubernetes.v0.2.1"},
+		}
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		subscription := getSubscription()
+		Expect(subscription.Spec.InstallPlanApproval).Should(Equal(v1alpha1.ApprovalManual))
+		Expect(subscription.Spec.StartingCSV).Should(Equal("mongodb-atlas-kubernetes.v0.2.1"))
+
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.1.0", "mongodb-atlas-kubernetes.v0.2.0", v1alpha1.SubscriptionStateUpgradePending)
+		createInstallPlan("install-2", "mongodb-atlas-kubernetes.v0.2.0")
+		expectInstallPlansReconciled("")
+		Expect(isApproved("install-2")).Should(BeTrue())
+		Expect(r.reconcileCSV(cr, ctx, &v1.DBaaSPlatformStatus{}, ownSubscription(), true, "", "")).Should(Equal(v1.ResultInProgress))
+
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.2.0", "mongodb-atlas-kubernetes.v0.2.1", v1alpha1.SubscriptionStateUpgradePending)
+		createInstallPlan("install-3", "mongodb-atlas-kubernetes.v0.2.1")
+		expectInstallPlansReconciled("")
+		Expect(isApproved("install-3")).Should(BeTrue())
+
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.2.1", "mongodb-atlas-kubernetes.v0.3.0", v1alpha1.SubscriptionStateUpgradePending)
+		createInstallPlan("install-4", "mongodb-atlas-kubernetes.v0.3.0")
+		expectInstallPlansReconciled("")
+		Expect(isApproved("install-4")).Should(BeFalse())
+
+		csv := reconcilers.GetClusterServiceVersion(namespace, "mongodb-atlas-kubernetes.v0.2.1")
+		Expect(c.Create(ctx, csv)).Should(Succeed())
+		status := &v1.DBaaSPlatformStatus{}
+		Expect(r.reconcileCSV(cr, ctx, status, ownSubscription(), true, "", "")).Should(Equal(v1.ResultInProgress))
+		Expect(r.reconcileCSV(cr, ctx, status, ownSubscription(), true, "", "")).Should(Equal(v1.ResultSuccess))
+		Expect(status.Providers).Should(HaveLen(1))
+		Expect(status.Providers[0].InstalledCSV).Should(Equal("mongodb-atlas-kubernetes.v0.2.1"))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(csv), csv)).Should(Succeed())
+		Expect(csv.OwnerReferences).Should(HaveLen(1))
+		Expect(csv.OwnerReferences[0].Name).Should(Equal(cr.Name))
+	})
+
+	It("should only approve the install plans batching the CSVs of other packages manually", func() {
+		cr.Spec.Providers = []v1.DBaaSPlatformProvider{
+			{Name: v1.MongoDBAtlasInstallation, InstallPlanApproval: "Manual"},
+		}
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		createInstallPlan("install-1", "crunchy-bridge-operator.v0.0.3", "mongodb-atlas-kubernetes.v0.2.0")
+		expectInstallPlansReconciled("the install plan install-1 also installs the CSVs crunchy-bridge-operator.v0.0.3 " +
+			"of other packages, and must be approved manually")
+		Expect(isApproved("install-1")).Should(BeFalse())
+
+		Expect(getProviderStatus(v1.MongoDBAtlasInstallation, getSubscription(), true, "", "blocked")).Should(Equal(
+			v1.DBaaSPlatformProviderStatus{Name: v1.MongoDBAtlasInstallation, ApprovalBlocked: "blocked"}))
+
+		// the install plans of other subscriptions are ignored
+		createInstallPlan("install-2", "mongodb-atlas-kubernetes.v0.2.0")
+		expectInstallPlansReconciled("the install plan install-1 also installs the CSVs crunchy-bridge-operator.v0.0.3 " +
+			"of other packages, and must be approved manually")
+		Expect(isApproved("install-2")).Should(BeTrue())
+	})
+
+	It("should report a pinned CSV older than the installed CSV", func() {
+		cr.Spec.Providers = []v1.DBaaSPlatformProvider{
+			{Name: v1.MongoDBAtlasInstallation, CSV: "mongodb-atlas-kubernetes.v0.2.0"},
+		}
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.2.1", "mongodb-atlas-kubernetes.v0.2.1", v1alpha1.SubscriptionStateAtLatest)
+		expectInstallPlansReconciled("the pinned CSV mongodb-atlas-kubernetes.v0.2.0 is older than the installed CSV " +
+			"mongodb-atlas-kubernetes.v0.2.1, operators cannot be downgraded")
+
+		status := &v1.DBaaSPlatformStatus{}
+		Expect(r.reconcileCSV(cr, ctx, status, ownSubscription(), true, "", "pinned CSV")).Should(Equal(v1.ResultInProgress))
+		Expect(status.Providers).Should(Equal([]v1.DBaaSPlatformProviderStatus{
+			{
+				Name:            v1.MongoDBAtlasInstallation,
+				InstalledCSV:    "mongodb-atlas-kubernetes.v0.2.1",
+				ApprovalBlocked: "pinned CSV",
+			},
+		}))
+	})
+
+	It("should compare the CSV versions of a package", func() {
+		Expect(isOlderCSV("mongodb-atlas-kubernetes.v0.2.0", "mongodb-atlas-kubernetes.v0.10.0")).Should(BeTrue())
+		Expect(isOlderCSV("mongodb-atlas-kubernetes.v0.2.0", "mongodb-atlas-kubernetes.v0.2.0")).Should(BeFalse())
+		Expect(isOlderCSV("crunchy-bridge-operator.v0.0.1", "mongodb-atlas-kubernetes.v0.2.0")).Should(BeFalse())
+		Expect(isOlderCSV("mongodb-atlas-kubernetes", "mongodb-atlas-kubernetes.v0.2.0")).Should(BeFalse())
+	})
+
+	It("should report a failed CSV and an unready operator", func() {
+		Expect(r.CheckHealth(ctx, cr)).Should(Equal("the subscription mongodb-atlas-subscription is missing"))
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.2.0", "mongodb-atlas-kubernetes.v0.2.0", v1alpha1.SubscriptionStateAtLatest)
+		Expect(r.CheckHealth(ctx, cr)).Should(Equal("the CSV mongodb-atlas-kubernetes.v0.2.0 is missing"))
+
+		csv := reconcilers.GetClusterServiceVersion(namespace, "mongodb-atlas-kubernetes.v0.2.0")
+		csv.Status.Phase = v1alpha1.CSVPhaseFailed
+		csv.Status.Message = "install strategy failed"
+		Expect(c.Create(ctx, csv)).Should(Succeed())
+		Expect(r.CheckHealth(ctx, cr)).Should(Equal("the CSV mongodb-atlas-kubernetes.v0.2.0 failed: install strategy failed"))
+
+		csv.Status.Phase = v1alpha1.CSVPhaseSucceeded
+		csv.Status.Message = ""
+		Expect(c.Update(ctx, csv)).Should(Succeed())
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: config.DeploymentName, Namespace: namespace},
+		}
+		Expect(c.Create(ctx, deployment)).Should(Succeed())
+		Expect(r.CheckHealth(ctx, cr)).Should(Equal("the deployment " + config.DeploymentName + " has no ready replicas"))
+
+		deployment.Status.ReadyReplicas = 1
+		Expect(c.Update(ctx, deployment)).Should(Succeed())
+		Expect(r.CheckHealth(ctx, cr)).Should(BeEmpty())
+	})
+
+	It("should delete the CRDs of the provider when purging", func() {
+		Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+		setInstalledCSV("mongodb-atlas-kubernetes.v0.2.0", "mongodb-atlas-kubernetes.v0.2.0", v1alpha1.SubscriptionStateAtLatest)
+		csv := reconcilers.GetClusterServiceVersion(namespace, "mongodb-atlas-kubernetes.v0.2.0")
+		csv.Spec.CustomResourceDefinitions.Owned = []v1alpha1.CRDDescription{
+			{Name: "atlasinventories.dbaas.redhat.com", Kind: "AtlasInventory", Version: "v1alpha1"},
+			{Name: "atlasconnections.dbaas.redhat.com", Kind: "AtlasConnection", Version: "v1alpha1"},
+		}
+		Expect(c.Create(ctx, csv)).Should(Succeed())
+		Expect(r.GetProviderKinds(ctx, cr)).Should(Equal([]string{"AtlasInventory", "AtlasConnection"}))
+
+		getCRD := func(name string) *unstructured.Unstructured {
+			crd := &unstructured.Unstructured{}
+			crd.SetAPIVersion("apiextensions.k8s.io/v1")
+			crd.SetKind(customResourceDefinitionKind)
+			crd.SetName(name)
+			return crd
+		}
+		for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+			Expect(c.Create(ctx, getCRD(crd.Name))).Should(Succeed())
+		}
+
+		cr.Spec.Uninstall = &v1.DBaaSPlatformUninstall{Purge: true}
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1.ResultSuccess))
+		for _, obj := range []client.Object{getCRD("atlasinventories.dbaas.redhat.com"), getCRD("atlasconnections.dbaas.redhat.com"),
+			csv, reconcilers.GetSubscription(namespace, config.Name+"-subscription")} {
+			Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(obj), obj))).Should(BeTrue())
+		}
+		Expect(r.GetProviderKinds(ctx, cr)).Should(BeEmpty())
+	})
+
+	It("should only delete the operator group created by the operator, once the providers are uninstalled", func() {
+		Expect(coreosv1.AddToScheme(scheme)).Should(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build()
+		r = NewReconciler(c, c, scheme, ctrl.Log, v1.MongoDBAtlasInstallation, config).(*Reconciler)
+		operatorGroup := reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, reconcilers.OPERATOR_GROUP_NAME)
+		cr.Namespace = reconcilers.INSTALL_NAMESPACE
+
+		Expect(r.reconcileOperatorGroup(ctx)).Should(Equal(v1.ResultSuccess))
+		Expect(r.reconcileSubscription(cr, ctx, reconcilers.GetSubscription(cr.Namespace, config.Name+"-subscription"))).Should(Equal(v1.ResultSuccess))
+		Expect(CleanupOperatorGroup(ctx, c)).Should(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(operatorGroup), operatorGroup)).Should(Succeed())
+		Expect(operatorGroup.Labels).Should(HaveKeyWithValue("managed-by", "dbaas-operator"))
+
+		Expect(r.Cleanup(ctx, cr)).Should(Equal(v1.ResultSuccess))
+		Expect(CleanupOperatorGroup(ctx, c)).Should(Succeed())
+		Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(operatorGroup), operatorGroup))).Should(BeTrue())
+
+		// an existing operator group is kept
+		Expect(c.Create(ctx, reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, reconcilers.OPERATOR_GROUP_NAME))).Should(Succeed())
+		Expect(r.reconcileOperatorGroup(ctx)).Should(Equal(v1.ResultSuccess))
+		Expect(CleanupOperatorGroup(ctx, c)).Should(Succeed())
+		operatorGroup = reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, reconcilers.OPERATOR_GROUP_NAME)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(operatorGroup), operatorGroup)).Should(Succeed())
+		Expect(operatorGroup.Labels).ShouldNot(HaveKey("managed-by"))
+	})
+
+	Context("with a subscription of the provider package created by an admin", func() {
+		var adminSubscription *v1alpha1.Subscription
+
+		BeforeEach(func() {
+			adminSubscription = &v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "mongodb-atlas", Namespace: "atlas-operator"},
+				Spec: &v1alpha1.SubscriptionSpec{
+					CatalogSource:          "certified-operators",
+					CatalogSourceNamespace: "openshift-marketplace",
+					Package:                config.PackageName,
+					Channel:                "stable",
+				},
+				Status: v1alpha1.SubscriptionStatus{InstalledCSV: "mongodb-atlas-kubernetes.v0.2.0"},
+			}
+			Expect(c.Create(ctx, adminSubscription)).Should(Succeed())
+			Expect(c.Create(ctx, reconcilers.GetClusterServiceVersion("atlas-operator", "mongodb-atlas-kubernetes.v0.2.0"))).Should(Succeed())
+			Expect(c.Create(ctx, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: config.DeploymentName, Namespace: "atlas-operator"},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+			})).Should(Succeed())
+		})
+
+		It("should use the subscription as is, and leave it installed on cleanup", func() {
+			status := &v1.DBaaSPlatformStatus{}
+			Expect(r.Reconcile(ctx, cr, status)).Should(Equal(v1.ResultSuccess))
+			Expect(status.Providers).Should(Equal([]v1.DBaaSPlatformProviderStatus{{
+				Name:                 v1.MongoDBAtlasInstallation,
+				InstalledCSV:         "mongodb-atlas-kubernetes.v0.2.0",
+				ExternalSubscription: "atlas-operator/mongodb-atlas",
+			}}))
+			err := c.Get(ctx, client.ObjectKeyFromObject(ownSubscription()), ownSubscription())
+			Expect(errors.IsNotFound(err)).Should(BeTrue())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(adminSubscription), adminSubscription)).Should(Succeed())
+			Expect(adminSubscription.Spec.Channel).Should(Equal("stable"))
+			Expect(adminSubscription.OwnerReferences).Should(BeEmpty())
+			Expect(r.CheckHealth(ctx, cr)).Should(BeEmpty())
+
+			Expect(r.Cleanup(ctx, cr)).Should(Equal(v1.ResultSuccess))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(adminSubscription), adminSubscription)).Should(Succeed())
+		})
+
+		It("should report the provider settings which cannot be applied", func() {
+			cr.Spec.Providers = []v1.DBaaSPlatformProvider{{Name: v1.MongoDBAtlasInstallation, Channel: "beta"}}
+			status := &v1.DBaaSPlatformStatus{}
+			Expect(r.Reconcile(ctx, cr, status)).Should(Equal(v1.ResultSuccess))
+			Expect(status.Providers[0].Conflict).Should(Equal("the provider settings are not applied to the subscription " +
+				"atlas-operator/mongodb-atlas of the package mongodb-atlas-kubernetes, not managed by the DBaaS operator"))
+
+			cr.Spec.Providers[0].AdoptSubscription = true
+			Expect(r.Reconcile(ctx, cr, status)).Should(Equal(v1.ResultSuccess))
+			Expect(status.Providers[0].Conflict).Should(Equal("the subscription atlas-operator/mongodb-atlas of the package " +
+				"mongodb-atlas-kubernetes cannot be adopted outside of the test-namespace namespace"))
+		})
+
+		It("should adopt the subscription of the DBaaSPlatform namespace", func() {
+			Expect(c.Delete(ctx, adminSubscription)).Should(Succeed())
+			adminSubscription.ResourceVersion = ""
+			adminSubscription.Namespace = namespace
+			Expect(c.Create(ctx, adminSubscription)).Should(Succeed())
+			cr.Spec.Providers = []v1.DBaaSPlatformProvider{{Name: v1.MongoDBAtlasInstallation, AdoptSubscription: true}}
+
+			subscription, managed, conflict, err := r.resolveSubscription(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed).Should(BeTrue())
+			Expect(conflict).Should(BeEmpty())
+			Expect(r.reconcileSubscription(cr, ctx, subscription)).Should(Equal(v1.ResultSuccess))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(adminSubscription), adminSubscription)).Should(Succeed())
+			Expect(metav1.IsControlledBy(adminSubscription, cr)).Should(BeTrue())
+			Expect(adminSubscription.Spec.Channel).Should(Equal(config.Channel))
+
+			// the adopted subscription is managed from now on
+			cr.Spec.Providers = nil
+			_, managed, _, err = r.resolveSubscription(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed).Should(BeTrue())
+		})
+
+		It("should report duplicate subscriptions", func() {
+			Expect(r.reconcileSubscription(cr, ctx, ownSubscription())).Should(Equal(v1.ResultSuccess))
+			subscription, managed, conflict, err := r.resolveSubscription(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managed).Should(BeTrue())
+			Expect(subscription.Name).Should(Equal(ownSubscription().Name))
+			Expect(conflict).Should(Equal("the package mongodb-atlas-kubernetes is subscribed by atlas-operator/mongodb-atlas, not managed by the DBaaS operator"))
+		})
+	})
+
+	It("should not modify an existing operator group", func() {
+		Expect(coreosv1.AddToScheme(scheme)).Should(Succeed())
+		existing := reconcilers.GetOperatorGroup(reconcilers.INSTALL_NAMESPACE, "openshift-operators")
+		existing.Spec.TargetNamespaces = []string{"team-a"}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr, existing).Build()
+		r = NewReconciler(c, c, scheme, ctrl.Log, v1.MongoDBAtlasInstallation, config).(*Reconciler)
+
+		Expect(r.reconcileOperatorGroup(ctx)).Should(Equal(v1.ResultSuccess))
+		operatorGroups := &coreosv1.OperatorGroupList{}
+		Expect(c.List(ctx, operatorGroups)).Should(Succeed())
+		Expect(operatorGroups.Items).Should(HaveLen(1))
+		Expect(operatorGroups.Items[0].Spec.TargetNamespaces).Should(Equal([]string{"team-a"}))
+	})
+})
@@ -0,0 +1,13 @@
+package providers_installation
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestProvidersInstallation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Providers Installation Suite")
+}
@@ -1,9 +1,16 @@
 package quickstart_installation
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
 	consolev1 "github.com/openshift/api/console/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -28,6 +35,23 @@ var QuickStarts = map[string][]byte{
 	"getting-started-with-openshift-database-access-for-developers":     gettingstarteddevQuickStart,
 }
 
+const (
+	// QuickStartConfigMapLabel labels the ConfigMaps of the operator namespace holding admin-supplied quick starts,
+	// each key holding a stream of ConsoleQuickStart YAML documents
+	QuickStartConfigMapLabel = "dbaas.redhat.com/quickstart"
+	// QuickStartLanguageAnnotation sets the language of the quick starts of a ConfigMap, installed as localized
+	// variants named <name>-<language>
+	QuickStartLanguageAnnotation = "dbaas.redhat.com/quickstart-language"
+	// QuickStartManagedAnnotation set to "false" on an installed quick start keeps the admin edits, the operator
+	// neither updates nor deletes it anymore
+	QuickStartManagedAnnotation = "dbaas.redhat.com/managed"
+	// QuickStartSourceLabel records the ConfigMap a quick start comes from, or builtin
+	QuickStartSourceLabel = "dbaas.redhat.com/quickstart-source"
+
+	quickStartLanguageLabel = "console.openshift.io/lang"
+	builtInSource           = "builtin"
+)
+
 type Reconciler struct {
 	client client.Client
 	logger logr.Logger
@@ -43,29 +67,136 @@ func NewReconciler(client client.Client, scheme *runtime.Scheme, logger logr.Log
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, cr *v1alpha1.DBaaSPlatform, platformStatus *v1alpha1.DBaaSPlatformStatus) (v1alpha1.PlatformsInstlnStatus, error) {
-	for qsName, qsBytes := range QuickStarts {
-		status, err := r.createQuickStartCR(qsName, qsBytes, ctx)
+	quickStarts, err := r.getQuickStarts(ctx, cr)
+	if err != nil {
+		return v1alpha1.ResultFailed, err
+	}
+	for _, quickStart := range quickStarts {
+		status, err := r.createQuickStartCR(quickStart, ctx)
 		if status != v1alpha1.ResultSuccess {
 			return status, err
 		}
 	}
-	return v1alpha1.ResultSuccess, nil
+	return r.deleteOrphanedQuickStarts(ctx, quickStarts)
 }
 
-func (r *Reconciler) createQuickStartCR(qsName string, qsBytes []byte, ctx context.Context) (v1alpha1.PlatformsInstlnStatus, error) {
-	quickStart := r.getQuickStartModel(qsName)
-	quickStartFromFile := &consolev1.ConsoleQuickStart{}
-	err := yaml.Unmarshal(qsBytes, quickStartFromFile)
+// getQuickStarts returns the quick starts to install: the built-in quick starts which are not disabled,
+// and the quick starts of the labelled ConfigMaps
+func (r *Reconciler) getQuickStarts(ctx context.Context, cr *v1alpha1.DBaaSPlatform) ([]*consolev1.ConsoleQuickStart, error) {
+	var disabled []string
+	if cr.Spec.QuickStarts != nil {
+		disabled = cr.Spec.QuickStarts.Disabled
+	}
+
+	var quickStarts []*consolev1.ConsoleQuickStart
+	for qsName, qsBytes := range QuickStarts {
+		if contains(disabled, qsName) {
+			continue
+		}
+		quickStartFromFile := &consolev1.ConsoleQuickStart{}
+		if err := yaml.Unmarshal(qsBytes, quickStartFromFile); err != nil {
+			return nil, err
+		}
+		quickStart := r.getQuickStartModel(qsName)
+		quickStart.Annotations = map[string]string{
+			"categories": "Database management",
+		}
+		quickStart.Labels = map[string]string{QuickStartSourceLabel: builtInSource}
+		quickStart.Spec = quickStartFromFile.Spec
+		quickStarts = append(quickStarts, quickStart)
+	}
 
-	if err == nil {
-		_, err = controllerutil.CreateOrUpdate(ctx, r.client, quickStart, func() error {
-			quickStart.Annotations = map[string]string{
-				"categories": "Database management",
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.client.List(ctx, configMaps, client.InNamespace(cr.Namespace), client.HasLabels{QuickStartConfigMapLabel}); err != nil {
+		return nil, err
+	}
+	for i := range configMaps.Items {
+		configMapQuickStarts, err := parseQuickStarts(&configMaps.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		quickStarts = append(quickStarts, configMapQuickStarts...)
+	}
+
+	sort.SliceStable(quickStarts, func(i, j int) bool {
+		return quickStarts[i].Name < quickStarts[j].Name
+	})
+	for i := 1; i < len(quickStarts); i++ {
+		if quickStarts[i].Name == quickStarts[i-1].Name {
+			return nil, fmt.Errorf("quick start %s is defined by both %s and %s", quickStarts[i].Name,
+				quickStarts[i-1].Labels[QuickStartSourceLabel], quickStarts[i].Labels[QuickStartSourceLabel])
+		}
+	}
+	return quickStarts, nil
+}
+
+// parseQuickStarts returns the quick starts of a ConfigMap, in the language of the ConfigMap if it sets one
+func parseQuickStarts(configMap *corev1.ConfigMap) ([]*consolev1.ConsoleQuickStart, error) {
+	keys := make([]string, 0, len(configMap.Data))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	language := configMap.Annotations[QuickStartLanguageAnnotation]
+	var quickStarts []*consolev1.ConsoleQuickStart
+	for _, key := range keys {
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(configMap.Data[key]), 4096)
+		for {
+			quickStart := &consolev1.ConsoleQuickStart{}
+			if err := decoder.Decode(quickStart); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, fmt.Errorf("invalid quick starts in %s of config map %s: %w", key, configMap.Name, err)
+			}
+			if quickStart.Kind == "" && quickStart.Name == "" {
+				continue
+			}
+			if quickStart.Kind != "ConsoleQuickStart" || quickStart.Name == "" {
+				return nil, fmt.Errorf("invalid quick starts in %s of config map %s: expecting named ConsoleQuickStart objects", key, configMap.Name)
 			}
-			quickStart.Spec = quickStartFromFile.Spec
-			return nil
-		})
+			if quickStart.Labels == nil {
+				quickStart.Labels = map[string]string{}
+			}
+			if language != "" {
+				quickStart.Labels[quickStartLanguageLabel] = language
+				if !strings.HasSuffix(quickStart.Name, "-"+language) {
+					quickStart.Name += "-" + language
+				}
+			}
+			quickStart.Labels[QuickStartSourceLabel] = configMap.Name
+			quickStarts = append(quickStarts, quickStart)
+		}
 	}
+	return quickStarts, nil
+}
+
+func (r *Reconciler) createQuickStartCR(quickStartFromSource *consolev1.ConsoleQuickStart, ctx context.Context) (v1alpha1.PlatformsInstlnStatus, error) {
+	quickStart := r.getQuickStartModel(quickStartFromSource.Name)
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(quickStart), quickStart)
+	if err != nil && !errors.IsNotFound(err) {
+		return v1alpha1.ResultFailed, err
+	}
+	// quick starts created by others are never taken over, the conflict is reported by CheckHealth, the built-in quick
+	// starts installed by previous releases are adopted
+	if err == nil && (!isOwned(quickStart) || !isManaged(quickStart)) {
+		if !isOwned(quickStart) {
+			r.logger.Info("Quick start conflict, not managed by the DBaaS operator", "quickStart", quickStart.Name)
+		}
+		return v1alpha1.ResultSuccess, nil
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, quickStart, func() error {
+		quickStart.Annotations = quickStartFromSource.Annotations
+		quickStart.Labels = quickStartFromSource.Labels
+		if quickStart.Labels == nil {
+			quickStart.Labels = map[string]string{}
+		}
+		quickStart.Labels[reconcilers.MANAGED_BY_LABEL] = reconcilers.MANAGED_BY_VALUE
+		quickStart.Spec = quickStartFromSource.Spec
+		return nil
+	})
 
 	if err != nil {
 		if errors.IsConflict(err) {
@@ -76,6 +207,114 @@ func (r *Reconciler) createQuickStartCR(qsName string, qsBytes []byte, ctx conte
 	return v1alpha1.ResultSuccess, nil
 }
 
+// CheckHealth reports the quick starts which are not installed, as quick starts with the same names were created by
+// others than the operator
+func (r *Reconciler) CheckHealth(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (string, error) {
+	quickStarts, err := r.getQuickStarts(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	var conflicts []string
+	for _, quickStartFromSource := range quickStarts {
+		quickStart := r.getQuickStartModel(quickStartFromSource.Name)
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(quickStart), quickStart); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		if !isOwned(quickStart) {
+			conflicts = append(conflicts, quickStart.Name)
+		}
+	}
+	if len(conflicts) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("the quick starts %s already exist and are not managed by the DBaaS operator", strings.Join(conflicts, ", ")), nil
+}
+
+// deleteOrphanedQuickStarts deletes the managed quick starts whose source is gone: a disabled built-in quick start,
+// or a deleted ConfigMap
+func (r *Reconciler) deleteOrphanedQuickStarts(ctx context.Context, quickStarts []*consolev1.ConsoleQuickStart) (v1alpha1.PlatformsInstlnStatus, error) {
+	installed, err := r.listManagedQuickStarts(ctx)
+	if err != nil {
+		return v1alpha1.ResultFailed, err
+	}
+	names := map[string]bool{}
+	for _, quickStart := range quickStarts {
+		names[quickStart.Name] = true
+	}
+	for i := range installed {
+		if names[installed[i].Name] {
+			continue
+		}
+		if err := r.client.Delete(ctx, &installed[i]); err != nil && !errors.IsNotFound(err) {
+			return v1alpha1.ResultFailed, err
+		}
+	}
+	return v1alpha1.ResultSuccess, nil
+}
+
+// listManagedQuickStarts returns the quick starts installed by the operator, including the unlabelled built-in quick
+// starts of previous releases, except the ones admins stopped managing
+func (r *Reconciler) listManagedQuickStarts(ctx context.Context) ([]consolev1.ConsoleQuickStart, error) {
+	quickStartList := &consolev1.ConsoleQuickStartList{}
+	if err := r.client.List(ctx, quickStartList, client.MatchingLabels{reconcilers.MANAGED_BY_LABEL: reconcilers.MANAGED_BY_VALUE}); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, quickStart := range quickStartList.Items {
+		names[quickStart.Name] = true
+	}
+	for qsName := range QuickStarts {
+		if names[qsName] {
+			continue
+		}
+		quickStart := r.getQuickStartModel(qsName)
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(quickStart), quickStart); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if isOwned(quickStart) {
+			quickStartList.Items = append(quickStartList.Items, *quickStart)
+		}
+	}
+
+	var quickStarts []consolev1.ConsoleQuickStart
+	for _, quickStart := range quickStartList.Items {
+		if isManaged(&quickStart) {
+			quickStarts = append(quickStarts, quickStart)
+		}
+	}
+	return quickStarts, nil
+}
+
+// isOwned returns whether the quick start was created by the operator: it is labelled as managed by the operator, or
+// it is a built-in quick start installed by a previous release, which didn't label them
+func isOwned(quickStart *consolev1.ConsoleQuickStart) bool {
+	managedBy, labelled := quickStart.Labels[reconcilers.MANAGED_BY_LABEL]
+	if labelled {
+		return managedBy == reconcilers.MANAGED_BY_VALUE
+	}
+	_, builtIn := QuickStarts[quickStart.Name]
+	return builtIn
+}
+
+func isManaged(quickStart *consolev1.ConsoleQuickStart) bool {
+	return quickStart.Annotations[QuickStartManagedAnnotation] != "false"
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Reconciler) getQuickStartModel(name string) *consolev1.ConsoleQuickStart {
 	return &consolev1.ConsoleQuickStart{
 		ObjectMeta: metav1.ObjectMeta{
@@ -87,10 +326,19 @@ func (r *Reconciler) getQuickStartModel(name string) *consolev1.ConsoleQuickStar
 func (r *Reconciler) Cleanup(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (v1alpha1.PlatformsInstlnStatus, error) {
 	for qsName := range QuickStarts {
 		quickstart := r.getQuickStartModel(qsName)
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(quickstart), quickstart); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return v1alpha1.ResultFailed, err
+		}
+		if !isOwned(quickstart) || !isManaged(quickstart) {
+			continue
+		}
 		err := r.client.Delete(ctx, quickstart)
 		if err != nil && !errors.IsNotFound(err) {
 			return v1alpha1.ResultFailed, err
 		}
 	}
-	return v1alpha1.ResultSuccess, nil
+	return r.deleteOrphanedQuickStarts(ctx, nil)
 }
@@ -1,13 +1,48 @@
 package quickstart_installation
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	consolev1 "github.com/openshift/api/console/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 )
 
+const adminQuickStart = "getting-started-with-openshift-database-access-for-administrators"
+const devQuickStart = "getting-started-with-openshift-database-access-for-developers"
+
+const configMapQuickStarts = `apiVersion: console.openshift.io/v1
+kind: ConsoleQuickStart
+metadata:
+  name: connect-my-database
+spec:
+  displayName: Connect my database
+  description: Connect the company database
+  durationMinutes: 5
+  introduction: Introduction
+---
+apiVersion: console.openshift.io/v1
+kind: ConsoleQuickStart
+metadata:
+  name: bind-my-database
+spec:
+  displayName: Bind my database
+  description: Bind the company database
+  durationMinutes: 5
+  introduction: Introduction
+`
+
 var _ = Describe("ConsoleQuickStart installation reconciler", func() {
 	Context("ConsoleQuickStart autogeneration", func() {
 		It("should unmarshal quickstart CR's correctly", func() {
@@ -39,3 +74,236 @@ var _ = Describe("ConsoleQuickStart installation reconciler", func() {
 		})
 	})
 })
+
+var _ = Describe("ConsoleQuickStart installation reconciler with admin-supplied quick starts", func() {
+	const namespace = "test-namespace"
+	ctx := context.Background()
+
+	var c client.Client
+	var cr *v1alpha1.DBaaSPlatform
+	var r *Reconciler
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(consolev1.AddToScheme(scheme)).Should(Succeed())
+		cr = &v1alpha1.DBaaSPlatform{
+			ObjectMeta: metav1.ObjectMeta{Name: "dbaas-platform", Namespace: namespace},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build()
+		r = NewReconciler(c, scheme, ctrl.Log).(*Reconciler)
+	})
+
+	reconcile := func() {
+		status, err := r.Reconcile(ctx, cr, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).Should(Equal(v1alpha1.ResultSuccess))
+	}
+	getQuickStart := func(name string) (*consolev1.ConsoleQuickStart, error) {
+		quickStart := &consolev1.ConsoleQuickStart{}
+		err := c.Get(ctx, client.ObjectKey{Name: name}, quickStart)
+		return quickStart, err
+	}
+	expectNotFound := func(name string) {
+		_, err := getQuickStart(name)
+		Expect(errors.IsNotFound(err)).Should(BeTrue())
+	}
+	createConfigMap := func(name string, annotations map[string]string) *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      map[string]string{QuickStartConfigMapLabel: ""},
+				Annotations: annotations,
+			},
+			Data: map[string]string{"quickstarts.yaml": configMapQuickStarts},
+		}
+		Expect(c.Create(ctx, configMap)).Should(Succeed())
+		return configMap
+	}
+
+	It("should install the built-in quick starts", func() {
+		reconcile()
+		quickStart, err := getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quickStart.Annotations).Should(HaveKeyWithValue("categories", "Database management"))
+		Expect(quickStart.Labels).Should(HaveKeyWithValue("managed-by", "dbaas-operator"))
+		Expect(quickStart.Labels).Should(HaveKeyWithValue(QuickStartSourceLabel, "builtin"))
+		Expect(quickStart.Spec.Tasks).ShouldNot(BeEmpty())
+	})
+
+	It("should not install or should delete the disabled built-in quick starts", func() {
+		reconcile()
+		cr.Spec.QuickStarts = &v1alpha1.DBaaSPlatformQuickStarts{Disabled: []string{devQuickStart}}
+		reconcile()
+		_, err := getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+		expectNotFound(devQuickStart)
+	})
+
+	It("should install the quick starts of the labelled config maps and delete them with the config map", func() {
+		configMap := createConfigMap("company-quickstarts", nil)
+		Expect(c.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-quickstarts", Namespace: namespace},
+			Data:       map[string]string{"quickstarts.yaml": "invalid"},
+		})).Should(Succeed())
+		reconcile()
+		quickStart, err := getQuickStart("connect-my-database")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quickStart.Spec.DisplayName).Should(Equal("Connect my database"))
+		Expect(quickStart.Labels).Should(HaveKeyWithValue("managed-by", "dbaas-operator"))
+		Expect(quickStart.Labels).Should(HaveKeyWithValue(QuickStartSourceLabel, "company-quickstarts"))
+		_, err = getQuickStart("bind-my-database")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.Delete(ctx, configMap)).Should(Succeed())
+		reconcile()
+		expectNotFound("connect-my-database")
+		expectNotFound("bind-my-database")
+		_, err = getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should install localized variants of the quick starts", func() {
+		createConfigMap("company-quickstarts", nil)
+		createConfigMap("company-quickstarts-fr", map[string]string{QuickStartLanguageAnnotation: "fr"})
+		reconcile()
+		_, err := getQuickStart("connect-my-database")
+		Expect(err).NotTo(HaveOccurred())
+		quickStart, err := getQuickStart("connect-my-database-fr")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quickStart.Labels).Should(HaveKeyWithValue("console.openshift.io/lang", "fr"))
+	})
+
+	It("should reject config maps with other objects than quick starts", func() {
+		Expect(c.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "company-quickstarts",
+				Namespace: namespace,
+				Labels:    map[string]string{QuickStartConfigMapLabel: ""},
+			},
+			Data: map[string]string{"quickstarts.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n"},
+		})).Should(Succeed())
+		status, err := r.Reconcile(ctx, cr, nil)
+		Expect(err).Should(HaveOccurred())
+		Expect(status).Should(Equal(v1alpha1.ResultFailed))
+	})
+
+	It("should keep the admin edits of unmanaged quick starts", func() {
+		configMap := createConfigMap("company-quickstarts", nil)
+		reconcile()
+		for _, name := range []string{adminQuickStart, "connect-my-database"} {
+			quickStart, err := getQuickStart(name)
+			Expect(err).NotTo(HaveOccurred())
+			quickStart.Annotations = map[string]string{QuickStartManagedAnnotation: "false"}
+			quickStart.Spec.DisplayName = "Edited"
+			Expect(c.Update(ctx, quickStart)).Should(Succeed())
+		}
+
+		Expect(c.Delete(ctx, configMap)).Should(Succeed())
+		reconcile()
+		for _, name := range []string{adminQuickStart, "connect-my-database"} {
+			quickStart, err := getQuickStart(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quickStart.Spec.DisplayName).Should(Equal("Edited"))
+		}
+
+		status, err := r.Cleanup(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).Should(Equal(v1alpha1.ResultSuccess))
+		_, err = getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+		expectNotFound(devQuickStart)
+	})
+
+	It("should not take over the quick starts created by others, and report the conflict", func() {
+		createConfigMap("company-quickstarts", nil)
+		Expect(c.Create(ctx, &consolev1.ConsoleQuickStart{
+			ObjectMeta: metav1.ObjectMeta{Name: adminQuickStart, Labels: map[string]string{"managed-by": "other-operator"}},
+			Spec:       consolev1.ConsoleQuickStartSpec{DisplayName: "Foreign"},
+		})).Should(Succeed())
+		Expect(c.Create(ctx, &consolev1.ConsoleQuickStart{
+			ObjectMeta: metav1.ObjectMeta{Name: "connect-my-database"},
+			Spec:       consolev1.ConsoleQuickStartSpec{DisplayName: "Foreign"},
+		})).Should(Succeed())
+		reconcile()
+		for _, name := range []string{adminQuickStart, "connect-my-database"} {
+			quickStart, err := getQuickStart(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quickStart.Spec.DisplayName).Should(Equal("Foreign"))
+			Expect(quickStart.Labels).ShouldNot(HaveKeyWithValue("managed-by", "dbaas-operator"))
+		}
+
+		reason, err := r.CheckHealth(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).Should(Equal("the quick starts connect-my-database, " + adminQuickStart +
+			" already exist and are not managed by the DBaaS operator"))
+
+		status, err := r.Cleanup(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).Should(Equal(v1alpha1.ResultSuccess))
+		for _, name := range []string{adminQuickStart, "connect-my-database"} {
+			_, err = getQuickStart(name)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should adopt the unlabelled built-in quick starts installed by previous releases", func() {
+		for _, name := range []string{adminQuickStart, devQuickStart} {
+			Expect(c.Create(ctx, &consolev1.ConsoleQuickStart{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{"categories": "Database management"}},
+				Spec:       consolev1.ConsoleQuickStartSpec{DisplayName: "Previous release"},
+			})).Should(Succeed())
+		}
+		reconcile()
+		quickStart, err := getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quickStart.Spec.DisplayName).ShouldNot(Equal("Previous release"))
+		Expect(quickStart.Labels).Should(HaveKeyWithValue("managed-by", "dbaas-operator"))
+		reason, err := r.CheckHealth(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).Should(BeEmpty())
+
+		// opted out quick starts are neither updated nor deleted, nor reported as conflicts
+		quickStart.Labels = nil
+		quickStart.Annotations = map[string]string{QuickStartManagedAnnotation: "false"}
+		quickStart.Spec.DisplayName = "Edited"
+		Expect(c.Update(ctx, quickStart)).Should(Succeed())
+		reconcile()
+		quickStart, err = getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quickStart.Spec.DisplayName).Should(Equal("Edited"))
+		reason, err = r.CheckHealth(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reason).Should(BeEmpty())
+
+		status, err := r.Cleanup(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).Should(Equal(v1alpha1.ResultSuccess))
+		_, err = getQuickStart(adminQuickStart)
+		Expect(err).NotTo(HaveOccurred())
+		expectNotFound(devQuickStart)
+	})
+
+	It("should delete the unlabelled built-in quick starts of previous releases once disabled", func() {
+		Expect(c.Create(ctx, &consolev1.ConsoleQuickStart{
+			ObjectMeta: metav1.ObjectMeta{Name: devQuickStart},
+			Spec:       consolev1.ConsoleQuickStartSpec{DisplayName: "Previous release"},
+		})).Should(Succeed())
+		cr.Spec.QuickStarts = &v1alpha1.DBaaSPlatformQuickStarts{Disabled: []string{devQuickStart}}
+		reconcile()
+		expectNotFound(devQuickStart)
+	})
+
+	It("should delete the managed quick starts on cleanup", func() {
+		createConfigMap("company-quickstarts", nil)
+		reconcile()
+		status, err := r.Cleanup(ctx, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).Should(Equal(v1alpha1.ResultSuccess))
+		expectNotFound(adminQuickStart)
+		expectNotFound(devQuickStart)
+		expectNotFound("connect-my-database")
+	})
+})
@@ -9,3 +9,16 @@ type PlatformReconciler interface {
 	Reconcile(ctx context.Context, cr *v1alpha1.DBaaSPlatform, status *v1alpha1.DBaaSPlatformStatus) (v1alpha1.PlatformsInstlnStatus, error)
 	Cleanup(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (v1alpha1.PlatformsInstlnStatus, error)
 }
+
+// PlatformHealthChecker is implemented by the platform reconcilers detecting the degradation of an installed platform
+type PlatformHealthChecker interface {
+	// CheckHealth returns why the installed platform is degraded, or an empty string if it is healthy
+	CheckHealth(ctx context.Context, cr *v1alpha1.DBaaSPlatform) (string, error)
+}
+
+// ProviderReconciler is implemented by the reconcilers of the provider platforms
+type ProviderReconciler interface {
+	PlatformReconciler
+	// GetProviderKinds returns the kinds of the custom resources defined by the installed provider operator
+	GetProviderKinds(ctx context.Context, cr *v1alpha1.DBaaSPlatform) ([]string, error)
+}
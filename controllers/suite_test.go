@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
@@ -53,6 +54,7 @@ var dRec *DBaaSReconciler
 var iCtrl *spyctrl
 var cCtrl *spyctrl
 var inCtrl *spyctrl
+var watchRegistry *ProviderWatchRegistry
 
 const (
 	testNamespace = "default"
@@ -112,6 +114,9 @@ var _ = BeforeSuite(func() {
 			&operatorframework.ClusterServiceVersion{},
 			&corev1.Secret{},
 		},
+		NewCache: cache.BuilderWithOptions(cache.Options{
+			SelectorsByObject: PlatformCacheSelectors(testNamespace),
+		}),
 	},
 	)
 	Expect(err).ToNot(HaveOccurred())
@@ -122,9 +127,13 @@ var _ = BeforeSuite(func() {
 		Scheme:           k8sManager.GetScheme(),
 		InstallNamespace: testNamespace,
 	}
+	// envtest doesn't serve the OpenShift authorization API, so RBAC is evaluated from the cache
 	authzReconciler := &DBaaSAuthzReconciler{
-		DBaaSReconciler:       dRec,
-		AuthorizationV1Client: oauthzclientv1.NewForConfigOrDie(cfg),
+		DBaaSReconciler: dRec,
+		AuthzBackend: &CachedAuthzBackend{
+			Backend:      &RBACAuthzBackend{Reader: k8sManager.GetClient()},
+			GrantsReader: k8sManager.GetClient(),
+		},
 	}
 
 	err = (&DBaaSTenantReconciler{
@@ -155,11 +164,20 @@ var _ = BeforeSuite(func() {
 	cCtrl = newSpyController(connectionCtrl)
 	inCtrl = newSpyController(instanceCtrl)
 
+	watchRegistry = &ProviderWatchRegistry{
+		Config: k8sManager.GetConfig(),
+		Scheme: k8sManager.GetScheme(),
+		Mapper: k8sManager.GetRESTMapper(),
+	}
+	err = k8sManager.Add(watchRegistry)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&DBaaSProviderReconciler{
 		DBaaSReconciler: dRec,
 		InventoryCtrl:   iCtrl,
 		ConnectionCtrl:  cCtrl,
 		InstanceCtrl:    inCtrl,
+		WatchRegistry:   watchRegistry,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -168,6 +186,11 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&DBaaSTenantQuotaReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctrl.SetupSignalHandler())
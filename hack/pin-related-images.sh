@@ -0,0 +1,28 @@
+#!/usr/bin/env bash
+#
+# Pins the related images of the generated bundle to their sha256 digest, so that the bundle deploys the exact images
+# it was built with and ImageContentSourcePolicy mirrors apply to them. Images already pinned are left untouched, and
+# the checked-in manifests are not modified.
+#
+# Usage: hack/pin-related-images.sh [file...]
+
+set -euo pipefail
+
+SKOPEO=${SKOPEO:-skopeo}
+FILES=("$@")
+if [ ${#FILES[@]} -eq 0 ]; then
+  FILES=(bundle/manifests/dbaas-operator.clusterserviceversion.yaml)
+fi
+
+images=$(sed -n -e '/RELATED_IMAGE_/{n;s/^ *value: *//p;}' "${FILES[@]}" | grep -v '@sha256:' | sort -u || true)
+for image in ${images}; do
+  repository=${image}
+  if [[ "${image##*/}" == *:* ]]; then
+    repository=${image%:*}
+  fi
+  digest=$(${SKOPEO} inspect --format '{{.Digest}}' "docker://${image}")
+  echo "${image} -> ${repository}@${digest}"
+  for file in "${FILES[@]}"; do
+    sed -i -e "s|\([ :]\)${image}\$|\1${repository}@${digest}|" "${file}"
+  done
+done
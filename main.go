@@ -38,14 +38,17 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/reconcilers"
 	operatorframework "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
@@ -75,9 +78,14 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var logLevel string
+	var authzBackend string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&logLevel, "log-level", "info", "Log level.")
+	flag.StringVar(&authzBackend, "authz-backend", "",
+		"The backend used to resolve tenant user access. "+
+			"Either \"openshift\", using ResourceAccessReviews, or \"rbac\", evaluating RBAC objects for clusters without the OpenShift authorization API. "+
+			"Defaults to \"openshift\" on OpenShift clusters, and to \"rbac\" otherwise.")
 
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
@@ -97,8 +105,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	cfg := ctrl.GetConfigOrDie()
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+	installNamespace, err := controllers.GetInstallNamespace()
+	if err != nil {
+		setupLog.Error(err, "unable to retrieve install namespace. default Tenant object cannot be installed")
+	}
+	managerOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
@@ -108,23 +119,65 @@ func main() {
 		ClientDisableCacheFor: []client.Object{
 			&operatorframework.ClusterServiceVersion{},
 			&corev1.Secret{},
+			&corev1.Pod{},
 		},
-	})
+	}
+	if installNamespace != "" {
+		managerOptions.NewCache = cache.BuilderWithOptions(cache.Options{
+			SelectorsByObject: controllers.PlatformCacheSelectors(installNamespace),
+		})
+	}
+	cfg := ctrl.GetConfigOrDie()
+	mgr, err := ctrl.NewManager(cfg, managerOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
 	DBaaSReconciler := &controllers.DBaaSReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		InstallNamespace: installNamespace,
 	}
-	if DBaaSReconciler.InstallNamespace, err = controllers.GetInstallNamespace(); err != nil {
-		setupLog.Error(err, "unable to retrieve install namespace. default Tenant object cannot be installed")
+	var ocpVersion string
+	info, err := openshift.GetPlatformInfo(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to get platform info")
 	}
+	if info.IsOpenShift() {
+		mappedVersion := openshift.MapKnownVersion(info)
+		if mappedVersion.Version != "" {
+			ocpVersion = semver.MajorMinor("v" + mappedVersion.Version)
+			setupLog.Info(fmt.Sprintf("OpenShift Version: %s", ocpVersion))
+		} else {
+			setupLog.Info("OpenShift version could not be determined.")
+		}
+	}
+
 	authzReconciler := &controllers.DBaaSAuthzReconciler{
-		DBaaSReconciler:       DBaaSReconciler,
-		AuthorizationV1Client: oauthzclientv1.NewForConfigOrDie(cfg),
+		DBaaSReconciler: DBaaSReconciler,
+	}
+	if authzBackend == "" {
+		// the OpenShift authorization API is only served by OpenShift clusters
+		authzBackend = controllers.RBACAuthzBackendName
+		if info.IsOpenShift() {
+			authzBackend = controllers.OpenShiftAuthzBackendName
+		}
+		setupLog.Info("Authz backend defaulted", "authzBackend", authzBackend)
+	}
+	switch authzBackend {
+	case controllers.OpenShiftAuthzBackendName:
+		authzReconciler.AuthzBackend = &controllers.OpenShiftAuthzBackend{AuthorizationV1Client: oauthzclientv1.NewForConfigOrDie(cfg)}
+	case controllers.RBACAuthzBackendName:
+		authzReconciler.AuthzBackend = &controllers.RBACAuthzBackend{Reader: mgr.GetClient()}
+	default:
+		setupLog.Error(fmt.Errorf("unknown authz backend %q", authzBackend), "unable to create authz backend")
+		os.Exit(1)
+	}
+	// reviews, and the bindings granting them, are cached until the RBAC objects which may grant them change
+	authzReconciler.AuthzBackend = &controllers.CachedAuthzBackend{
+		Backend:      authzReconciler.AuthzBackend,
+		GrantsReader: mgr.GetClient(),
 	}
 	if err = (&controllers.DBaaSTenantAuthzReconciler{
 		DBaaSAuthzReconciler: authzReconciler,
@@ -159,11 +212,25 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSDefaultTenant")
 		os.Exit(1)
 	}
+	watchRegistry := &controllers.ProviderWatchRegistry{
+		Config: mgr.GetConfig(),
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+	}
+	if err = mgr.Add(watchRegistry); err != nil {
+		setupLog.Error(err, "unable to set up provider watch registry")
+		os.Exit(1)
+	}
+	if err = mgr.AddMetricsExtraHandler(controllers.ProviderWatchRegistryPath, watchRegistry); err != nil {
+		setupLog.Error(err, "unable to set up provider watch registry handler")
+		os.Exit(1)
+	}
 	if err = (&controllers.DBaaSProviderReconciler{
 		DBaaSReconciler: DBaaSReconciler,
 		ConnectionCtrl:  connectionCtrl,
 		InventoryCtrl:   inventoryCtrl,
 		InstanceCtrl:    instanceCtrl,
+		WatchRegistry:   watchRegistry,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSProvider")
 		os.Exit(1)
@@ -183,6 +250,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSTenant")
 			os.Exit(1)
 		}
+		if err = (&v1alpha1.DBaaSInstance{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSInstance")
+			os.Exit(1)
+		}
+		if err = (&v1alpha1.DBaaSProvider{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSProvider")
+			os.Exit(1)
+		}
+		if err = (&v1alpha1.DBaaSPlatform{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSPlatform")
+			os.Exit(1)
+		}
 	}
 	if err = (&controllers.DBaaSTenantReconciler{
 		DBaaSAuthzReconciler: authzReconciler,
@@ -190,25 +269,25 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSTenant")
 		os.Exit(1)
 	}
+	if err = (&controllers.DBaaSTenantQuotaReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSTenantQuota")
+		os.Exit(1)
+	}
 
-	var ocpVersion string
-	info, err := openshift.GetPlatformInfo(mgr.GetConfig())
+	capabilities, err := reconcilers.DiscoverCapabilities(discovery.NewDiscoveryClientForConfigOrDie(cfg))
 	if err != nil {
-		setupLog.Error(err, "unable to get platform info")
-	}
-	if info.IsOpenShift() {
-		mappedVersion := openshift.MapKnownVersion(info)
-		if mappedVersion.Version != "" {
-			ocpVersion = semver.MajorMinor("v" + mappedVersion.Version)
-			setupLog.Info(fmt.Sprintf("OpenShift Version: %s", ocpVersion))
-		} else {
-			setupLog.Info("OpenShift version could not be determined.")
-		}
+		setupLog.Error(err, "unable to discover the cluster capabilities")
+		os.Exit(1)
 	}
+	setupLog.Info("Cluster capabilities", "olm", capabilities.OLM, "consolePlugins", capabilities.ConsolePlugins,
+		"consoleQuickStarts", capabilities.ConsoleQuickStarts)
 	if err = (&controllers.DBaaSPlatformReconciler{
 		DBaaSReconciler: DBaaSReconciler,
 		Log:             ctrl.Log.WithName("controllers").WithName("DBaaSPlatform"),
 		OcpVersion:      ocpVersion,
+		Capabilities:    capabilities,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSPlatform")
 		os.Exit(1)
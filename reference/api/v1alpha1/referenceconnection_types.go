@@ -0,0 +1,49 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ReferenceConnection is the Schema for the reference provider connection API, which generates the credentials
+// and connection information of a discovered instance
+type ReferenceConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   dbaasv1alpha1.DBaaSConnectionSpec   `json:"spec,omitempty"`
+	Status dbaasv1alpha1.DBaaSConnectionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReferenceConnectionList contains a list of ReferenceConnections
+type ReferenceConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceConnection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReferenceConnection{}, &ReferenceConnectionList{})
+}
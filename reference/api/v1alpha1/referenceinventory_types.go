@@ -0,0 +1,49 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ReferenceInventory is the Schema for the reference provider inventory API, which discovers the instances of a
+// simulated database service account
+type ReferenceInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   dbaasv1alpha1.DBaaSInventorySpec   `json:"spec,omitempty"`
+	Status dbaasv1alpha1.DBaaSInventoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReferenceInventoryList contains a list of ReferenceInventories
+type ReferenceInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReferenceInventory{}, &ReferenceInventoryList{})
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/reference/api/v1alpha1"
+)
+
+const (
+	// ProviderName is the name of the DBaaSProvider registering the reference provider
+	ProviderName = "reference-provider"
+
+	// the kinds of the reference provider resources
+	InventoryKind  = "ReferenceInventory"
+	ConnectionKind = "ReferenceConnection"
+	InstanceKind   = "ReferenceInstance"
+
+	// the credential fields of the simulated database service account
+	CredentialAccountName   = "accountName"
+	CredentialSeedInstances = "seedInstances"
+
+	// SimulateFailureAnnotation makes the reconciliation of the annotated inventory, connection or instance fail,
+	// with the annotation value as error message
+	SimulateFailureAnnotation = "reference.dbaas.redhat.com/simulate-failure"
+
+	// the condition reasons reported by the reference provider
+	ReasonSyncOK       = "SyncOK"
+	ReasonInputError   = "InputError"
+	ReasonBackendError = "BackendError"
+	ReasonNotFound     = "NotFound"
+	ReasonProvisioning = "Provisioning"
+)
+
+// Provider returns the DBaaSProvider registering the reference provider
+func Provider() *dbaasv1alpha1.DBaaSProvider {
+	return &dbaasv1alpha1.DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ProviderName,
+			Labels: map[string]string{
+				"related-to": "dbaas-operator",
+				"type":       "dbaas-provider-registration",
+			},
+		},
+		Spec: dbaasv1alpha1.DBaaSProviderSpec{
+			Provider: dbaasv1alpha1.DatabaseProvider{
+				Name:               "Red Hat DBaaS / Reference Provider",
+				DisplayName:        "Reference Provider",
+				DisplayDescription: "A simulated database service, for developing and testing without a database provider account.",
+				Icon: dbaasv1alpha1.ProviderIcon{
+					Data:      "PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciIHZpZXdCb3g9IjAgMCAzMiAzMiI+PGNpcmNsZSBjeD0iMTYiIGN5PSIxNiIgcj0iMTQiIGZpbGw9IiMwNmMiLz48L3N2Zz4=",
+					MediaType: "image/svg+xml",
+				},
+			},
+			InventoryKind:  InventoryKind,
+			ConnectionKind: ConnectionKind,
+			InstanceKind:   InstanceKind,
+			CredentialFields: []dbaasv1alpha1.CredentialField{
+				{
+					Key:         CredentialAccountName,
+					DisplayName: "Account Name",
+					Type:        dbaasv1alpha1.FieldTypeString,
+					Required:    true,
+				},
+				{
+					Key:         CredentialSeedInstances,
+					DisplayName: "Existing Instances (comma separated)",
+					Type:        dbaasv1alpha1.FieldTypeString,
+					Required:    false,
+				},
+			},
+			AllowsFreeTrial:              true,
+			ExternalProvisionURL:         "",
+			ExternalProvisionDescription: "Instances of the reference provider are simulated, they can only be provisioned through DBaaSInstances.",
+			InstanceParameterSpecs: []dbaasv1alpha1.InstanceParameterSpec{
+				{
+					Name:        "name",
+					DisplayName: "Instance Name",
+					Type:        dbaasv1alpha1.FieldTypeString,
+					Required:    true,
+				},
+				{
+					Name:         "cloudProvider",
+					DisplayName:  "Cloud Provider",
+					Type:         dbaasv1alpha1.FieldTypeString,
+					Required:     false,
+					DefaultValue: "Simulated",
+				},
+				{
+					Name:         "cloudRegion",
+					DisplayName:  "Cloud Region",
+					Type:         dbaasv1alpha1.FieldTypeString,
+					Required:     false,
+					DefaultValue: "local",
+				},
+			},
+			Capabilities: &dbaasv1alpha1.DBaaSProviderCapabilities{
+				Provisioning: pointer.Bool(true),
+				Deletion:     pointer.Bool(true),
+			},
+		},
+	}
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=dbaasproviders,verbs=get;create;update
+
+// RegisterProvider creates or updates the DBaaSProvider registering the reference provider
+func RegisterProvider(ctx context.Context, c client.Client) error {
+	provider := &dbaasv1alpha1.DBaaSProvider{ObjectMeta: metav1.ObjectMeta{Name: ProviderName}}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, provider, func() error {
+		expected := Provider()
+		provider.Labels = expected.Labels
+		provider.Spec = expected.Spec
+		return nil
+	})
+	return err
+}
+
+// returns the simulated failure requested by the annotation of an object, if any
+func simulatedFailure(obj client.Object) (string, bool) {
+	msg, ok := obj.GetAnnotations()[SimulateFailureAnnotation]
+	if ok && len(msg) == 0 {
+		msg = "simulated failure"
+	}
+	return msg, ok
+}
+
+// returns the namespaced name of the inventory referenced by an object, in the object namespace by default
+func inventoryKey(ref dbaasv1alpha1.NamespacedName, namespace string) types.NamespacedName {
+	if len(ref.Namespace) > 0 {
+		namespace = ref.Namespace
+	}
+	return types.NamespacedName{Name: ref.Name, Namespace: namespace}
+}
+
+// returns whether an inventory has discovered the instances of its account
+func isInventoryReady(inventory *v1alpha1.ReferenceInventory) bool {
+	return apimeta.IsStatusConditionTrue(inventory.Status.Conditions, dbaasv1alpha1.DBaaSInventoryProviderSyncType)
+}
+
+// reads the simulated database service account of an inventory from its credentials secret
+func getAccount(ctx context.Context, c client.Client, inventory *v1alpha1.ReferenceInventory) (accountName string, seedInstances []string, err error) {
+	if inventory.Spec.CredentialsRef == nil {
+		return "", nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, inventoryKey(*inventory.Spec.CredentialsRef, inventory.Namespace), secret); err != nil {
+		return "", nil, err
+	}
+	for _, name := range strings.Split(string(secret.Data[CredentialSeedInstances]), ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			seedInstances = append(seedInstances, name)
+		}
+	}
+	return string(secret.Data[CredentialAccountName]), seedInstances, nil
+}
+
+// sets a condition and updates the status, requeuing on conflicts
+func updateStatus(ctx context.Context, c client.Client, obj client.Object, conditions *[]metav1.Condition, cond metav1.Condition) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	cond.ObservedGeneration = obj.GetGeneration()
+	apimeta.SetStatusCondition(conditions, cond)
+	if err := c.Status().Update(ctx, obj); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("Object modified, retry syncing status")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
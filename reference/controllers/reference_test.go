@@ -0,0 +1,227 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/reference/api/v1alpha1"
+)
+
+var _ = Describe("Reference provider", func() {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reference-credentials",
+			Namespace: testNamespace,
+		},
+		StringData: map[string]string{
+			CredentialAccountName:   "dev",
+			CredentialSeedInstances: "orders, inventory",
+		},
+	}
+	inventory := &v1alpha1.ReferenceInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reference-inventory",
+			Namespace: testNamespace,
+		},
+		Spec: dbaasv1alpha1.DBaaSInventorySpec{
+			CredentialsRef: &dbaasv1alpha1.NamespacedName{
+				Name:      secret.Name,
+				Namespace: testNamespace,
+			},
+		},
+	}
+	BeforeEach(assertResourceCreationIfNotExists(secret))
+	BeforeEach(assertResourceCreationIfNotExists(inventory))
+
+	It("should register the reference provider", func() {
+		Expect(RegisterProvider(ctx, k8sClient)).Should(Succeed())
+		provider := &dbaasv1alpha1.DBaaSProvider{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, client.ObjectKey{Name: ProviderName}, provider)
+		}, timeout).Should(Succeed())
+		Expect(provider.Spec).Should(Equal(Provider().Spec))
+		Expect(RegisterProvider(ctx, k8sClient)).Should(Succeed())
+	})
+
+	It("should discover the seed instances of the inventory account", func() {
+		Eventually(func() []string {
+			return getInstanceIDs(inventory)
+		}, timeout).Should(Equal([]string{"dev-inventory", "dev-orders"}))
+	})
+
+	It("should report inventories without valid credentials", func() {
+		invalidInventory := inventory.DeepCopy()
+		invalidInventory.Name = "reference-inventory-no-credentials"
+		invalidInventory.Spec.CredentialsRef.Name = "missing-credentials"
+		invalidInventory.SetResourceVersion("")
+		Expect(k8sClient.Create(ctx, invalidInventory)).Should(Succeed())
+		defer assertResourceDeletion(invalidInventory)()
+		Eventually(func() string {
+			return getConditionReason(invalidInventory, &invalidInventory.Status.Conditions, dbaasv1alpha1.DBaaSInventoryProviderSyncType)
+		}, timeout).Should(Equal(ReasonInputError))
+	})
+
+	It("should provision instances through the Pending, Creating and Ready phases", func() {
+		instance := &v1alpha1.ReferenceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reference-instance",
+				Namespace: testNamespace,
+			},
+			Spec: dbaasv1alpha1.DBaaSInstanceSpec{
+				InventoryRef: dbaasv1alpha1.NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				Name:          "payments",
+				CloudProvider: "Simulated",
+				CloudRegion:   "local",
+			},
+		}
+		Expect(k8sClient.Create(ctx, instance)).Should(Succeed())
+		defer assertResourceDeletion(instance)()
+
+		var phases []string
+		Eventually(func() []string {
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), instance); err == nil {
+				if len(phases) == 0 || phases[len(phases)-1] != instance.Status.Phase {
+					phases = append(phases, instance.Status.Phase)
+				}
+			}
+			return phases
+		}, timeout, phaseDuration/10).Should(ContainElement(dbaasv1alpha1.InstancePhaseReady))
+		Expect(phases).Should(ContainElements(dbaasv1alpha1.InstancePhasePending, dbaasv1alpha1.InstancePhaseCreating))
+		Expect(instance.Status.InstanceID).Should(Equal("dev-payments"))
+		Expect(instance.Status.InstanceInfo).Should(HaveKeyWithValue("cloudRegion", "local"))
+
+		Eventually(func() []string {
+			return getInstanceIDs(inventory)
+		}, timeout).Should(ContainElement("dev-payments"))
+	})
+
+	It("should report simulated failures", func() {
+		instance := &v1alpha1.ReferenceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reference-instance-failure",
+				Namespace: testNamespace,
+				Annotations: map[string]string{
+					SimulateFailureAnnotation: "quota exceeded",
+				},
+			},
+			Spec: dbaasv1alpha1.DBaaSInstanceSpec{
+				InventoryRef: dbaasv1alpha1.NamespacedName{
+					Name: inventory.Name,
+				},
+				Name: "failure",
+			},
+		}
+		Expect(k8sClient.Create(ctx, instance)).Should(Succeed())
+		defer assertResourceDeletion(instance)()
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), instance); err != nil {
+				return ""
+			}
+			return instance.Status.Phase
+		}, timeout).Should(Equal(dbaasv1alpha1.InstancePhaseFailed))
+		cond := apimeta.FindStatusCondition(instance.Status.Conditions, dbaasv1alpha1.DBaaSInstanceProviderSyncType)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Reason).Should(Equal(ReasonBackendError))
+		Expect(cond.Message).Should(Equal("quota exceeded"))
+	})
+
+	Context("after creating a connection to a discovered instance", func() {
+		connection := &v1alpha1.ReferenceConnection{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reference-connection",
+				Namespace: testNamespace,
+			},
+			Spec: dbaasv1alpha1.DBaaSConnectionSpec{
+				InventoryRef: dbaasv1alpha1.NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				InstanceID: "dev-orders",
+			},
+		}
+		BeforeEach(assertResourceCreationIfNotExists(connection))
+
+		It("should generate the connection credentials and information", func() {
+			Eventually(func() string {
+				return getConditionReason(connection, &connection.Status.Conditions, dbaasv1alpha1.DBaaSConnectionProviderSyncType)
+			}, timeout).Should(Equal(ReasonSyncOK))
+			Expect(connection.Status.CredentialsRef).ShouldNot(BeNil())
+			Expect(connection.Status.ConnectionInfoRef).ShouldNot(BeNil())
+
+			credentials := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: connection.Status.CredentialsRef.Name, Namespace: testNamespace}, credentials)).Should(Succeed())
+			Expect(credentials.Data).Should(HaveKey("username"))
+			Expect(credentials.Data["password"]).ShouldNot(BeEmpty())
+			Expect(credentials.OwnerReferences).Should(HaveLen(1))
+
+			connectionInfo := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: connection.Status.ConnectionInfoRef.Name, Namespace: testNamespace}, connectionInfo)).Should(Succeed())
+			Expect(connectionInfo.Data).Should(HaveKeyWithValue("database", "orders"))
+		})
+	})
+})
+
+func getInstanceIDs(inventory *v1alpha1.ReferenceInventory) []string {
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(inventory), inventory); err != nil {
+		return nil
+	}
+	var instanceIDs []string
+	for _, instance := range inventory.Status.Instances {
+		instanceIDs = append(instanceIDs, instance.InstanceID)
+	}
+	return instanceIDs
+}
+
+func getConditionReason(obj client.Object, conditions *[]metav1.Condition, condType string) string {
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return ""
+	}
+	if cond := apimeta.FindStatusCondition(*conditions, condType); cond != nil {
+		return cond.Reason
+	}
+	return ""
+}
+
+func assertResourceCreationIfNotExists(object client.Object) func() {
+	return func() {
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(object), object); errors.IsNotFound(err) {
+			object.SetResourceVersion("")
+			Expect(k8sClient.Create(ctx, object)).Should(Succeed())
+		}
+	}
+}
+
+func assertResourceDeletion(object client.Object) func() {
+	return func() {
+		Expect(k8sClient.Delete(ctx, object)).Should(Succeed())
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(object), object))
+		}, timeout).Should(BeTrue())
+	}
+}
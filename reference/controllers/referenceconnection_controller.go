@@ -0,0 +1,193 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/reference/api/v1alpha1"
+)
+
+// ReferenceConnectionReconciler generates the credentials Secret and connection information ConfigMap of
+// ReferenceConnections to instances discovered by their inventory
+type ReferenceConnectionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=referenceconnections,verbs=get;list;watch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=referenceconnections/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;list;watch;create;update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ReferenceConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var connection v1alpha1.ReferenceConnection
+	if err := r.Get(ctx, req.NamespacedName, &connection); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Reference Connection resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Reference Connection for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	cond := metav1.Condition{
+		Type:   dbaasv1alpha1.DBaaSConnectionProviderSyncType,
+		Status: metav1.ConditionFalse,
+	}
+	if msg, failed := simulatedFailure(&connection); failed {
+		cond.Reason = ReasonBackendError
+		cond.Message = msg
+		return updateStatus(ctx, r.Client, &connection, &connection.Status.Conditions, cond)
+	}
+
+	var inventory v1alpha1.ReferenceInventory
+	if err := r.Get(ctx, inventoryKey(connection.Spec.InventoryRef, connection.Namespace), &inventory); err != nil {
+		if errors.IsNotFound(err) {
+			cond.Reason = ReasonNotFound
+			cond.Message = "The inventory was not found"
+			return updateStatus(ctx, r.Client, &connection, &connection.Status.Conditions, cond)
+		}
+		logger.Error(err, "Error fetching Reference Inventory of the Reference Connection")
+		return ctrl.Result{}, err
+	}
+	var instance *dbaasv1alpha1.Instance
+	for i := range inventory.Status.Instances {
+		if inventory.Status.Instances[i].InstanceID == connection.Spec.InstanceID {
+			instance = &inventory.Status.Instances[i]
+		}
+	}
+	if instance == nil {
+		cond.Reason = ReasonNotFound
+		cond.Message = fmt.Sprintf("The instance %s was not discovered by the inventory", connection.Spec.InstanceID)
+		return updateStatus(ctx, r.Client, &connection, &connection.Status.Conditions, cond)
+	}
+
+	secret, err := r.reconcileCredentials(ctx, &connection)
+	if err != nil {
+		logger.Error(err, "Error reconciling the credentials of the Reference Connection")
+		return ctrl.Result{}, err
+	}
+	configMap, err := r.reconcileConnectionInfo(ctx, &connection, instance)
+	if err != nil {
+		logger.Error(err, "Error reconciling the connection information of the Reference Connection")
+		return ctrl.Result{}, err
+	}
+	connection.Status.CredentialsRef = &corev1.LocalObjectReference{Name: secret.Name}
+	connection.Status.ConnectionInfoRef = &corev1.LocalObjectReference{Name: configMap.Name}
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = ReasonSyncOK
+	cond.Message = "Connection information and credentials generated"
+	return updateStatus(ctx, r.Client, &connection, &connection.Status.Conditions, cond)
+}
+
+// creates the credentials secret of a connection, the generated password is kept on updates
+func (r *ReferenceConnectionReconciler) reconcileCredentials(ctx context.Context, connection *v1alpha1.ReferenceConnection) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      connection.Name + "-credentials",
+			Namespace: connection.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Labels = map[string]string{
+			dbaasv1alpha1.TypeLabelKey: dbaasv1alpha1.TypeLabelValue,
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data["username"] = []byte("reference")
+		if len(secret.Data["password"]) == 0 {
+			password := make([]byte, 16)
+			if _, err := rand.Read(password); err != nil {
+				return err
+			}
+			secret.Data["password"] = []byte(hex.EncodeToString(password))
+		}
+		return controllerutil.SetControllerReference(connection, secret, r.Scheme)
+	})
+	return secret, err
+}
+
+// creates the connection information config map of a connection to an instance
+func (r *ReferenceConnectionReconciler) reconcileConnectionInfo(ctx context.Context, connection *v1alpha1.ReferenceConnection,
+	instance *dbaasv1alpha1.Instance) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      connection.Name + "-configs",
+			Namespace: connection.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"type":     "postgresql",
+			"provider": Provider().Spec.Provider.Name,
+			"host":     fmt.Sprintf("%s.reference.local", instance.InstanceID),
+			"port":     "5432",
+			"database": instance.Name,
+		}
+		return controllerutil.SetControllerReference(connection, configMap, r.Scheme)
+	})
+	return configMap, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ReferenceConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ReferenceConnection{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		// connections are ready once the inventory discovers their instance
+		Watches(
+			&source.Kind{Type: &v1alpha1.ReferenceInventory{}},
+			handler.EnqueueRequestsFromMapFunc(r.inventoryMapFunc),
+		).
+		Complete(r)
+}
+
+// maps an inventory to the connections referencing it
+func (r *ReferenceConnectionReconciler) inventoryMapFunc(o client.Object) []reconcile.Request {
+	var connectionList v1alpha1.ReferenceConnectionList
+	if err := r.List(context.Background(), &connectionList); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, connection := range connectionList.Items {
+		if inventoryKey(connection.Spec.InventoryRef, connection.Namespace) == client.ObjectKeyFromObject(o) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&connection)})
+		}
+	}
+	return requests
+}
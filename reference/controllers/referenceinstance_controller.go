@@ -0,0 +1,166 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/reference/api/v1alpha1"
+)
+
+// ReferenceInstanceReconciler simulates provisioning ReferenceInstances in the database service account of their inventory,
+// moving them through the Pending, Creating and Ready phases
+type ReferenceInstanceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// PhaseDuration is how long provisioning stays in the Pending and Creating phases
+	PhaseDuration time.Duration
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=referenceinstances,verbs=get;list;watch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=referenceinstances/status,verbs=get;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ReferenceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var instance v1alpha1.ReferenceInstance
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Reference Instance resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Reference Instance for reconcile")
+		return ctrl.Result{}, err
+	}
+	// deleting an instance is immediate, the inventory stops discovering it
+	if instance.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if msg, failed := simulatedFailure(&instance); failed {
+		return r.setPhase(ctx, &instance, dbaasv1alpha1.InstancePhaseFailed, ReasonBackendError, msg)
+	}
+	// updates of provisioned instances are not simulated
+	if instance.Status.Phase == dbaasv1alpha1.InstancePhaseReady {
+		return ctrl.Result{}, nil
+	}
+
+	var inventory v1alpha1.ReferenceInventory
+	if err := r.Get(ctx, inventoryKey(instance.Spec.InventoryRef, instance.Namespace), &inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return r.setPhase(ctx, &instance, dbaasv1alpha1.InstancePhasePending, ReasonNotFound, "The inventory was not found")
+		}
+		logger.Error(err, "Error fetching Reference Inventory of the Reference Instance")
+		return ctrl.Result{}, err
+	}
+	if !isInventoryReady(&inventory) {
+		return r.setPhase(ctx, &instance, dbaasv1alpha1.InstancePhasePending, ReasonInputError, "The inventory is not ready")
+	}
+
+	cond := apimeta.FindStatusCondition(instance.Status.Conditions, dbaasv1alpha1.DBaaSInstanceProviderSyncType)
+	if cond == nil || cond.Reason != ReasonProvisioning {
+		return r.setPhase(ctx, &instance, dbaasv1alpha1.InstancePhasePending, ReasonProvisioning, "Provisioning pending")
+	}
+	if remaining := r.PhaseDuration - time.Since(cond.LastTransitionTime.Time); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if instance.Status.Phase == dbaasv1alpha1.InstancePhasePending {
+		return r.setPhase(ctx, &instance, dbaasv1alpha1.InstancePhaseCreating, ReasonProvisioning, "Provisioning in progress")
+	}
+
+	accountName, _, err := getAccount(ctx, r.Client, &inventory)
+	if err != nil {
+		logger.Error(err, "Error fetching the account of the Reference Inventory")
+		return ctrl.Result{}, err
+	}
+	instance.Status.InstanceID = fmt.Sprintf("%s-%s", accountName, instance.Spec.Name)
+	instance.Status.InstanceInfo = map[string]string{
+		CredentialAccountName: accountName,
+		"cloudProvider":       instance.Spec.CloudProvider,
+		"cloudRegion":         instance.Spec.CloudRegion,
+	}
+	for name, value := range instance.Spec.OtherInstanceParams {
+		instance.Status.InstanceInfo[name] = value
+	}
+	return r.setPhase(ctx, &instance, dbaasv1alpha1.InstancePhaseReady, ReasonSyncOK, "Provisioning complete")
+}
+
+// moves the instance to a phase, restarting the phase duration when the phase or its reason change
+func (r *ReferenceInstanceReconciler) setPhase(ctx context.Context, instance *v1alpha1.ReferenceInstance, phase string, reason string, message string) (ctrl.Result, error) {
+	cond := metav1.Condition{
+		Type:    dbaasv1alpha1.DBaaSInstanceProviderSyncType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+	if phase == dbaasv1alpha1.InstancePhaseReady {
+		cond.Status = metav1.ConditionTrue
+	}
+	if existing := apimeta.FindStatusCondition(instance.Status.Conditions, cond.Type); existing != nil &&
+		existing.Reason == reason && instance.Status.Phase == phase {
+		return ctrl.Result{}, nil
+	}
+	apimeta.RemoveStatusCondition(&instance.Status.Conditions, cond.Type)
+	instance.Status.Phase = phase
+	result, err := updateStatus(ctx, r.Client, instance, &instance.Status.Conditions, cond)
+	if err != nil || result.Requeue || reason != ReasonProvisioning {
+		return result, err
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: r.PhaseDuration}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ReferenceInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ReferenceInstance{}).
+		// provisioning starts once the inventory is ready
+		Watches(
+			&source.Kind{Type: &v1alpha1.ReferenceInventory{}},
+			handler.EnqueueRequestsFromMapFunc(r.inventoryMapFunc),
+		).
+		Complete(r)
+}
+
+// maps an inventory to the instances provisioned in it
+func (r *ReferenceInstanceReconciler) inventoryMapFunc(o client.Object) []reconcile.Request {
+	var instanceList v1alpha1.ReferenceInstanceList
+	if err := r.List(context.Background(), &instanceList); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, instance := range instanceList.Items {
+		if inventoryKey(instance.Spec.InventoryRef, instance.Namespace) == client.ObjectKeyFromObject(o) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&instance)})
+		}
+	}
+	return requests
+}
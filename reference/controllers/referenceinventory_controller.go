@@ -0,0 +1,170 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	dbaasv1alpha1 "github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/reference/api/v1alpha1"
+)
+
+// ReferenceInventoryReconciler discovers the instances of the simulated database service account of ReferenceInventories:
+// the seed instances of the account credentials, and the ready ReferenceInstances provisioned in the inventory
+type ReferenceInventoryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=referenceinventories,verbs=get;list;watch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=referenceinventories/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ReferenceInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var inventory v1alpha1.ReferenceInventory
+	if err := r.Get(ctx, req.NamespacedName, &inventory); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Reference Inventory resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Reference Inventory for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	instances, cond, err := r.discoverInstances(ctx, &inventory)
+	if err != nil {
+		logger.Error(err, "Error discovering the Reference Inventory instances")
+		return ctrl.Result{}, err
+	}
+	inventory.Status.Instances = instances
+	return updateStatus(ctx, r.Client, &inventory, &inventory.Status.Conditions, cond)
+}
+
+// lists the instances of the inventory account, with the condition reporting the discovery outcome
+func (r *ReferenceInventoryReconciler) discoverInstances(ctx context.Context, inventory *v1alpha1.ReferenceInventory) ([]dbaasv1alpha1.Instance, metav1.Condition, error) {
+	cond := metav1.Condition{
+		Type:   dbaasv1alpha1.DBaaSInventoryProviderSyncType,
+		Status: metav1.ConditionFalse,
+	}
+	if msg, failed := simulatedFailure(inventory); failed {
+		cond.Reason = ReasonBackendError
+		cond.Message = msg
+		return nil, cond, nil
+	}
+
+	accountName, seedInstances, err := getAccount(ctx, r.Client, inventory)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, cond, err
+		}
+		cond.Reason = ReasonInputError
+		cond.Message = "The credentials secret was not found"
+		return nil, cond, nil
+	}
+	if len(accountName) == 0 {
+		cond.Reason = ReasonInputError
+		cond.Message = fmt.Sprintf("The credentials secret is missing the %s field", CredentialAccountName)
+		return nil, cond, nil
+	}
+
+	var instances []dbaasv1alpha1.Instance
+	for _, name := range seedInstances {
+		instances = append(instances, dbaasv1alpha1.Instance{
+			InstanceID: fmt.Sprintf("%s-%s", accountName, name),
+			Name:       name,
+			InstanceInfo: map[string]string{
+				CredentialAccountName: accountName,
+			},
+		})
+	}
+
+	// instances can be provisioned in any namespace allowed to reference the inventory
+	var instanceList v1alpha1.ReferenceInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, cond, err
+	}
+	for _, instance := range instanceList.Items {
+		if inventoryKey(instance.Spec.InventoryRef, instance.Namespace) != client.ObjectKeyFromObject(inventory) ||
+			instance.Status.Phase != dbaasv1alpha1.InstancePhaseReady || instance.DeletionTimestamp != nil {
+			continue
+		}
+		instances = append(instances, dbaasv1alpha1.Instance{
+			InstanceID:   instance.Status.InstanceID,
+			Name:         instance.Spec.Name,
+			InstanceInfo: instance.Status.InstanceInfo,
+		})
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].InstanceID < instances[j].InstanceID
+	})
+
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = ReasonSyncOK
+	cond.Message = fmt.Sprintf("Discovered %d instances in account %s", len(instances), accountName)
+	return instances, cond, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ReferenceInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ReferenceInventory{}).
+		// provisioned instances are discovered once ready, and no longer once deleted
+		Watches(
+			&source.Kind{Type: &v1alpha1.ReferenceInstance{}},
+			handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+				instance := o.(*v1alpha1.ReferenceInstance)
+				return []reconcile.Request{{NamespacedName: inventoryKey(instance.Spec.InventoryRef, instance.Namespace)}}
+			}),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.credentialsMapFunc),
+		).
+		Complete(r)
+}
+
+// maps a secret to the inventories using it as credentials
+func (r *ReferenceInventoryReconciler) credentialsMapFunc(o client.Object) []reconcile.Request {
+	var inventoryList v1alpha1.ReferenceInventoryList
+	if err := r.List(context.Background(), &inventoryList); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, inventory := range inventoryList.Items {
+		if inventory.Spec.CredentialsRef != nil &&
+			inventoryKey(*inventory.Spec.CredentialsRef, inventory.Namespace) == client.ObjectKeyFromObject(o) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventory)})
+		}
+	}
+	return requests
+}